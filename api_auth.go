@@ -10,6 +10,13 @@ import (
 	"time"
 )
 
+const (
+	appTokenRefreshAhead   = 10 * time.Minute       // AppToken 提前刷新的最大时长
+	tokenStoreLeaseTTL     = 30 * time.Second       // 刷新 AppToken 时在 TokenStore 中持有租约的时长
+	tokenStorePollBackoff  = 200 * time.Millisecond // 租约被其他节点持有时轮询 TokenStore 的间隔
+	appTokenRefreshTimeout = 30 * time.Second       // 单次刷新 AppToken 的最长时间
+)
+
 type GetAppTokenRes struct {
 	AccessToken string `json:"access_token"`
 	ExpiresIn   int64  `json:"expires_in"`
@@ -21,21 +28,215 @@ type GetAppTokenRes struct {
 // 若ttl = 0 则 token 永久有效
 // 注意: VIP5集群 ttl 单位为毫秒, 其他集群 ttl 单位为秒
 func (c *Client) GetAppToken(ctx context.Context, ttl int64) (appToken *GetAppTokenRes, err error) {
+	issuedAt := time.Now()
+	if appToken, err = c.requestAppToken(ctx, ttl); err != nil {
+		return nil, err
+	}
+	c.tokenMu.Lock()
+	c.storeAppToken(appToken, issuedAt)
+	expireAt, store := c.appTokenExpireAt, c.tokenStore
+	c.tokenMu.Unlock()
+	if store != nil {
+		if err = store.Set(ctx, c.appKey, appToken.AccessToken, expireAt); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// SetAppToken 设置AppToken, 一般用于分布式部署的时候，业务层统一维护AppToken，为节点设置AppToken
+// 通过该方法设置的 token 不会被提前刷新, 仅在请求返回401时重新获取
+// 多节点共享 AppToken 也可以使用 SetTokenStore, 由 Client 自动维护
+func (c *Client) SetAppToken(appToken string) {
+	c.tokenMu.Lock()
+	c.appToken, c.appTokenExpireAt = appToken, time.Time{}
+	c.tokenMu.Unlock()
+}
+
+// SetAppTokenTTL 设置自动获取AppToken时使用的ttl, 取值含义同 GetAppToken, 默认为 -1
+func (c *Client) SetAppTokenTTL(ttl int64) {
+	c.tokenMu.Lock()
+	c.appTokenTTL = ttl
+	c.tokenMu.Unlock()
+}
+
+// SetTokenStore 设置 AppToken 共享存储, 多个节点使用同一个 TokenStore 时只有一个节点会刷新 AppToken
+func (c *Client) SetTokenStore(store TokenStore) {
+	c.tokenMu.Lock()
+	c.tokenStore = store
+	c.tokenMu.Unlock()
+}
+
+// SetTTLInMillisecond 设置 ttl 及 expires_in 的单位是否为毫秒, VIP5集群需设置为 true
+func (c *Client) SetTTLInMillisecond(ttlInMillisecond bool) {
+	c.tokenMu.Lock()
+	c.ttlInMillisecond = ttlInMillisecond
+	c.tokenMu.Unlock()
+}
+
+func (c *Client) requestAppToken(ctx context.Context, ttl int64) (appToken *GetAppTokenRes, err error) {
 	data := map[string]any{"grant_type": "client_credentials", "client_id": c.clientId, "client_secret": c.clientSecret}
 	if ttl >= 0 {
 		data["ttl"] = ttl
 	}
 	appToken = new(GetAppTokenRes)
-	if err = c.doReq(ctx, http.MethodPost, "token", nil, data, appToken); err != nil {
+	resp, err := c.reqClient.R().SetContext(ctx).SetBodyJsonMarshal(data).Post("token")
+	if err != nil {
+		return nil, err
+	}
+	if err = c.parseResponse(resp, appToken); err != nil {
 		return nil, err
 	}
-	c.SetAppToken(appToken.AccessToken)
 	return
 }
 
-// SetAppToken 设置AppToken, 一般用于分布式部署的时候，业务层统一维护AppToken，为节点设置AppToken
-func (c *Client) SetAppToken(appToken string) {
-	c.appToken = appToken
+// storeAppToken 保存AppToken并计算提前刷新的时间点, 调用方需持有 tokenMu 写锁
+func (c *Client) storeAppToken(appToken *GetAppTokenRes, issuedAt time.Time) {
+	c.appToken, c.appTokenExpireAt = appToken.AccessToken, time.Time{}
+	if appToken.ExpiresIn <= 0 {
+		return
+	}
+	unit := time.Second
+	if c.ttlInMillisecond {
+		unit = time.Millisecond
+	}
+	lifetime := time.Duration(appToken.ExpiresIn) * unit
+	c.appTokenExpireAt = issuedAt.Add(lifetime - min(lifetime/2, appTokenRefreshAhead))
+}
+
+// appTokenValid 调用方需持有 tokenMu 读锁或写锁
+func (c *Client) appTokenValid() bool {
+	return len(c.appToken) > 0 && (c.appTokenExpireAt.IsZero() || time.Now().Before(c.appTokenExpireAt))
+}
+
+// validAppToken 返回可用的AppToken, 不存在或即将过期时自动获取
+func (c *Client) validAppToken(ctx context.Context) (string, error) {
+	c.tokenMu.RLock()
+	appToken, valid := c.appToken, c.appTokenValid()
+	c.tokenMu.RUnlock()
+	if valid {
+		return appToken, nil
+	}
+	return c.refreshAppToken(ctx, appToken)
+}
+
+// tokenRefresh 进行中的AppToken刷新, done 关闭后 token 及 err 可读
+type tokenRefresh struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// refreshAppToken 重新获取AppToken, staleToken 为调用方认为已失效的 token
+// 并发调用时只有一个刷新在进行, 其余调用等待其结果; 等待期间 ctx 结束时立即返回 ctx.Err()
+// 刷新在独立的 goroutine 中进行, 不持有 tokenMu, 也不会因发起方的 ctx 取消而中断
+func (c *Client) refreshAppToken(ctx context.Context, staleToken string) (string, error) {
+	c.tokenMu.Lock()
+	if c.appToken != staleToken && c.appTokenValid() {
+		appToken := c.appToken
+		c.tokenMu.Unlock()
+		return appToken, nil
+	}
+	refresh := c.tokenRefresh
+	if refresh == nil {
+		refresh = &tokenRefresh{done: make(chan struct{})}
+		c.tokenRefresh = refresh
+		go c.runTokenRefresh(ctx, refresh, staleToken, c.tokenStore)
+	}
+	c.tokenMu.Unlock()
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-refresh.done:
+		return refresh.token, refresh.err
+	}
+}
+
+func (c *Client) runTokenRefresh(ctx context.Context, refresh *tokenRefresh, staleToken string, store TokenStore) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), appTokenRefreshTimeout)
+	defer cancel()
+	if store == nil {
+		refresh.token, _, refresh.err = c.fetchAppToken(ctx)
+	} else {
+		refresh.token, refresh.err = c.refreshStoredAppToken(ctx, staleToken, store)
+	}
+	c.tokenMu.Lock()
+	c.tokenRefresh = nil
+	c.tokenMu.Unlock()
+	close(refresh.done)
+}
+
+// refreshStoredAppToken 优先使用 TokenStore 中的 token, 并通过租约保证只有一个节点访问 token 接口
+func (c *Client) refreshStoredAppToken(ctx context.Context, staleToken string, store TokenStore) (string, error) {
+	for {
+		if appToken, err := c.loadStoredAppToken(ctx, staleToken, store); err != nil || len(appToken) > 0 {
+			return appToken, err
+		}
+		locked, err := store.Lock(ctx, c.appKey, c.tokenOwner, tokenStoreLeaseTTL)
+		if err != nil {
+			return "", err
+		}
+		if locked {
+			return c.fetchStoredAppToken(ctx, staleToken, store)
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(tokenStorePollBackoff):
+		}
+	}
+}
+
+// fetchAppToken 访问 token 接口获取AppToken并保存
+func (c *Client) fetchAppToken(ctx context.Context) (appToken string, expireAt time.Time, err error) {
+	c.tokenMu.RLock()
+	ttl := c.appTokenTTL
+	c.tokenMu.RUnlock()
+	issuedAt := time.Now()
+	res, err := c.requestAppToken(ctx, ttl)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.storeAppToken(res, issuedAt)
+	return c.appToken, c.appTokenExpireAt, nil
+}
+
+// fetchStoredAppToken 持有租约后获取AppToken并写入 TokenStore
+func (c *Client) fetchStoredAppToken(ctx context.Context, staleToken string, store TokenStore) (appToken string, err error) {
+	defer func() {
+		if unlockErr := store.Unlock(context.WithoutCancel(ctx), c.appKey, c.tokenOwner); err == nil {
+			err = unlockErr
+		}
+	}()
+	// 获取租约前其他节点可能刚刚完成刷新
+	if appToken, err = c.loadStoredAppToken(ctx, staleToken, store); err != nil || len(appToken) > 0 {
+		return appToken, err
+	}
+	appToken, expireAt, err := c.fetchAppToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err = store.Set(ctx, c.appKey, appToken, expireAt); err != nil {
+		return "", err
+	}
+	return
+}
+
+// loadStoredAppToken 从 TokenStore 读取可用的AppToken并保存, 没有可用的 token 时返回空字符串
+func (c *Client) loadStoredAppToken(ctx context.Context, staleToken string, store TokenStore) (string, error) {
+	appToken, expireAt, err := store.Get(ctx, c.appKey)
+	if err != nil {
+		return "", err
+	}
+	if len(appToken) == 0 || appToken == staleToken || (!expireAt.IsZero() && !time.Now().Before(expireAt)) {
+		return "", nil
+	}
+	c.tokenMu.Lock()
+	c.appToken, c.appTokenExpireAt = appToken, expireAt
+	c.tokenMu.Unlock()
+	return appToken, nil
 }
 
 type GetUserTokenRes struct {
@@ -0,0 +1,202 @@
+package easemob_server_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tokenServer 模拟 token 接口及一个需要鉴权的 ping 接口
+type tokenServer struct {
+	expiresIn  int64         // token 接口返回的 expires_in
+	tokenDelay time.Duration // token 接口的响应延迟
+	tokenCalls atomic.Int32
+	pingCalls  atomic.Int32
+	rejectAll  bool // ping 接口对所有 token 返回401
+
+	mu    sync.Mutex
+	valid map[string]bool
+}
+
+func (s *tokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/org/app/token":
+		n := s.tokenCalls.Add(1)
+		time.Sleep(s.tokenDelay)
+		token := fmt.Sprintf("token-%d", n)
+		s.mu.Lock()
+		if s.valid == nil {
+			s.valid = make(map[string]bool)
+		}
+		s.valid[token] = true
+		s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":%q,"expires_in":%d,"application":"app"}`, token, s.expiresIn)
+	case "/org/app/ping":
+		s.pingCalls.Add(1)
+		s.mu.Lock()
+		valid := s.valid[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		s.mu.Unlock()
+		if !valid || s.rejectAll {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"unauthorized","error_description":"token invalid"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":"pong"}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// newTokenTestClient 返回访问 s 的 Client, 清空预设的 AppToken 使 Client 自动获取
+func newTokenTestClient(t *testing.T, s *tokenServer, opts ...Option) *Client {
+	t.Helper()
+	c := newTestClient(t, s, append([]Option{WithTTLInMillisecond()}, opts...)...)
+	c.SetAppToken("")
+	return c
+}
+
+func ping(ctx context.Context, c *Client) error {
+	res := new(BaseRes[string])
+	return c.doReq(ctx, http.MethodGet, "ping", nil, nil, res)
+}
+
+func TestAppTokenLazyFetch(t *testing.T) {
+	s := &tokenServer{expiresIn: 3600 * 1000}
+	c := newTokenTestClient(t, s)
+	if n := s.tokenCalls.Load(); n != 0 {
+		t.Fatalf("token fetched before first request: %d calls", n)
+	}
+	for i := 0; i < 3; i++ {
+		if err := ping(context.Background(), c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := s.tokenCalls.Load(); n != 1 {
+		t.Fatalf("expected 1 token call, got %d", n)
+	}
+}
+
+func TestAppTokenRefreshAhead(t *testing.T) {
+	// expires_in 为200毫秒时 token 在签发100毫秒后提前刷新
+	s := &tokenServer{expiresIn: 200}
+	c := newTokenTestClient(t, s)
+	if err := ping(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if err := ping(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.tokenCalls.Load(); n != 1 {
+		t.Fatalf("expected 1 token call before refresh point, got %d", n)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if err := ping(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.tokenCalls.Load(); n != 2 {
+		t.Fatalf("expected token refreshed ahead of expiry, got %d token calls", n)
+	}
+}
+
+func TestAppTokenRetryOn401(t *testing.T) {
+	s := &tokenServer{}
+	c := newTokenTestClient(t, s)
+	c.SetAppToken("revoked")
+	if err := ping(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.tokenCalls.Load(); n != 1 {
+		t.Fatalf("expected 1 token call, got %d", n)
+	}
+	if n := s.pingCalls.Load(); n != 2 {
+		t.Fatalf("expected request retried once, got %d calls", n)
+	}
+}
+
+func TestAppTokenRetryOn401Once(t *testing.T) {
+	s := &tokenServer{rejectAll: true}
+	c := newTokenTestClient(t, s)
+	c.SetAppToken("revoked")
+	var apiErr ApiError
+	if err := ping(context.Background(), c); !errors.As(err, &apiErr) {
+		t.Fatalf("expected ApiError, got %v", err)
+	}
+	if n := s.pingCalls.Load(); n != 2 {
+		t.Fatalf("expected exactly one retry, got %d calls", n)
+	}
+}
+
+func TestAppTokenConcurrentRefresh(t *testing.T) {
+	s := &tokenServer{expiresIn: 3600 * 1000, tokenDelay: 100 * time.Millisecond}
+	c := newTokenTestClient(t, s)
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- ping(context.Background(), c)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := s.tokenCalls.Load(); n != 1 {
+		t.Fatalf("expected concurrent refreshes collapsed into 1 token call, got %d", n)
+	}
+}
+
+func TestAppTokenRefreshWaitHonorsContext(t *testing.T) {
+	s := &tokenServer{expiresIn: 3600 * 1000, tokenDelay: 300 * time.Millisecond}
+	c := newTokenTestClient(t, s)
+	go func() { _ = ping(context.Background(), c) }()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := ping(ctx, c); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("waiter blocked %v past its deadline", elapsed)
+	}
+	if err := ping(context.Background(), c); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.tokenCalls.Load(); n != 1 {
+		t.Fatalf("expected 1 token call, got %d", n)
+	}
+}
+
+func TestGetAppTokenConcurrentSetTokenStore(t *testing.T) {
+	s := &tokenServer{expiresIn: 3600 * 1000}
+	c := newTokenTestClient(t, s)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.SetTokenStore(NewMemoryTokenStore())
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetAppToken(context.Background(), -1); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
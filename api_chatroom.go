@@ -0,0 +1,242 @@
+package easemob_server_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type NewChatroom struct {
+	Name        string   `json:"name"`                  // 聊天室名称, 最大长度为128字符
+	Description string   `json:"description,omitempty"` // 聊天室描述, 最大长度为512字符
+	MaxUsers    int      `json:"maxusers,omitempty"`    // 聊天室最大成员数(包括聊天室所有者), 默认为1000
+	Owner       string   `json:"owner"`                 // 聊天室所有者用户名
+	Members     []string `json:"members,omitempty"`     // 聊天室成员用户名, 不包括所有者
+	Custom      string   `json:"custom,omitempty"`      // 聊天室扩展信息
+}
+
+type CreateChatroomResData struct {
+	Id string `json:"id"` // 聊天室 ID
+}
+
+// CreateChatroom 创建聊天室
+func (c *Client) CreateChatroom(ctx context.Context, chatroom NewChatroom) (res *BaseRes[CreateChatroomResData], err error) {
+	if len(chatroom.Name) == 0 || len(chatroom.Owner) == 0 {
+		return nil, errors.New("name or owner is empty")
+	}
+	res = new(BaseRes[CreateChatroomResData])
+	if err = c.doReq(ctx, http.MethodPost, "chatrooms", nil, chatroom, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ChatroomInfo 聊天室详情
+type ChatroomInfo struct {
+	Id                string             `json:"id"`                 // 聊天室 ID
+	Name              string             `json:"name"`               // 聊天室名称
+	Description       string             `json:"description"`        // 聊天室描述
+	MaxUsers          int                `json:"maxusers"`           // 聊天室最大成员数
+	Owner             string             `json:"owner"`              // 聊天室所有者用户名
+	Created           int64              `json:"created"`            // 创建时间, Unix 时间戳, 单位为毫秒
+	Custom            string             `json:"custom"`             // 聊天室扩展信息
+	Mute              bool               `json:"mute"`               // 是否已开启全员禁言
+	AffiliationsCount int                `json:"affiliations_count"` // 聊天室成员数(包括所有者)
+	Affiliations      []GroupAffiliation `json:"affiliations"`       // 聊天室成员列表, 角色为 owner 或 member
+}
+
+// GetChatroom 获取聊天室详情, 一次最多获取100个聊天室
+func (c *Client) GetChatroom(ctx context.Context, chatroomIds []string) (res *BaseRes[[]ChatroomInfo], err error) {
+	if len(chatroomIds) == 0 {
+		return nil, errors.New("minimum count of chatroom is 1")
+	} else if len(chatroomIds) > 100 {
+		return nil, errors.New("maximum count of chatroom is 100")
+	}
+	pathSuffix := fmt.Sprintf("chatrooms/%s", strings.Join(chatroomIds, ","))
+	res = new(BaseRes[[]ChatroomInfo])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// EditChatroom 修改聊天室信息, 为 nil 的字段不修改
+type EditChatroom struct {
+	Name        *string `json:"name,omitempty"`        // 聊天室名称
+	Description *string `json:"description,omitempty"` // 聊天室描述
+	MaxUsers    *int    `json:"maxusers,omitempty"`    // 聊天室最大成员数
+	Custom      *string `json:"custom,omitempty"`      // 聊天室扩展信息
+}
+
+// UpdateChatroom 修改聊天室信息
+// 返回结果 Data 的 key 为修改的字段名, value 为是否修改成功
+func (c *Client) UpdateChatroom(ctx context.Context, chatroomId string, chatroom EditChatroom) (res *BaseRes[map[string]bool], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s", chatroomId)
+	res = new(BaseRes[map[string]bool])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, chatroom, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type DeleteChatroomResData struct {
+	Success bool   `json:"success"`
+	Id      string `json:"id"`
+}
+
+// DeleteChatroom 删除聊天室
+func (c *Client) DeleteChatroom(ctx context.Context, chatroomId string) (res *BaseRes[DeleteChatroomResData], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s", chatroomId)
+	res = new(BaseRes[DeleteChatroomResData])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ChatroomListItem 聊天室列表项
+type ChatroomListItem struct {
+	Id                string `json:"id"`                 // 聊天室 ID
+	Name              string `json:"name"`               // 聊天室名称
+	Owner             string `json:"owner"`              // 聊天室所有者用户名
+	AffiliationsCount int    `json:"affiliations_count"` // 聊天室成员数(包括所有者)
+}
+
+// ListChatrooms 分页获取 App 下的聊天室
+func (c *Client) ListChatrooms(ctx context.Context, limit int, cursor string) (res *PageRes[[]ChatroomListItem], err error) {
+	if limit <= 0 {
+		limit = 10
+	} else if limit > 100 {
+		limit = 100
+	}
+	params := map[string]any{"limit": limit, "cursor": cursor}
+	res = new(PageRes[[]ChatroomListItem])
+	if err = c.doReq(ctx, http.MethodGet, "chatrooms", params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type UserJoinedChatroom struct {
+	Id   string `json:"id"`   // 聊天室 ID
+	Name string `json:"name"` // 聊天室名称
+}
+
+// ListUserJoinedChatrooms 分页获取用户加入的聊天室, pageNum 从1开始
+func (c *Client) ListUserJoinedChatrooms(ctx context.Context, username string, pageNum, pageSize int) (res *BaseRes[[]UserJoinedChatroom], err error) {
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	} else if pageSize > 1000 {
+		pageSize = 1000
+	}
+	params := map[string]any{"pagenum": pageNum, "pagesize": pageSize}
+	pathSuffix := fmt.Sprintf("users/%s/joined_chatrooms", username)
+	res = new(BaseRes[[]UserJoinedChatroom])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetChatroomMembers 分页获取聊天室成员及其角色, pageNum 从1开始
+func (c *Client) GetChatroomMembers(ctx context.Context, chatroomId string, pageNum, pageSize int) (res *BaseRes[[]GroupAffiliation], err error) {
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	} else if pageSize > 1000 {
+		pageSize = 1000
+	}
+	params := map[string]any{"pagenum": pageNum, "pagesize": pageSize}
+	pathSuffix := fmt.Sprintf("chatrooms/%s/users", chatroomId)
+	res = new(BaseRes[[]GroupAffiliation])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// AddChatroomMember 添加单个聊天室成员
+func (c *Client) AddChatroomMember(ctx context.Context, chatroomId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/users/%s", chatroomId, username)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, nil, false)
+}
+
+// BatchAddChatroomMember 批量添加聊天室成员, 一次最多添加60个
+// 已在聊天室中或不存在的用户不会出现在添加成功的列表中
+func (c *Client) BatchAddChatroomMember(ctx context.Context, chatroomId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	pathSuffix := fmt.Sprintf("chatrooms/%s/users", chatroomId)
+	return c.batchAddMember(ctx, pathSuffix, usernames)
+}
+
+// DelChatroomMember 移除单个聊天室成员
+func (c *Client) DelChatroomMember(ctx context.Context, chatroomId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/users/%s", chatroomId, username)
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, false)
+}
+
+// BatchDelChatroomMember 批量移除聊天室成员, 一次最多移除60个
+func (c *Client) BatchDelChatroomMember(ctx context.Context, chatroomId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	pathSuffix := fmt.Sprintf("chatrooms/%s/users/%s", chatroomId, strings.Join(usernames, ","))
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, true)
+}
+
+type AddChatroomSuperAdminResData struct {
+	Result   string `json:"result"` // 添加结果, 成功时为 success
+	Resource string `json:"resource"`
+}
+
+// AddChatroomSuperAdmin 添加聊天室超级管理员, 超级管理员可以创建聊天室
+func (c *Client) AddChatroomSuperAdmin(ctx context.Context, username string) (res *BaseRes[AddChatroomSuperAdminResData], err error) {
+	data := map[string]any{"superadmin": username}
+	res = new(BaseRes[AddChatroomSuperAdminResData])
+	if err = c.doReq(ctx, http.MethodPost, "chatrooms/super_admin", nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type DelChatroomSuperAdminResData struct {
+	NewSuperAdmin string `json:"newSuperAdmin"` // 被撤销的超级管理员用户名
+	Resource      string `json:"resource"`
+}
+
+// DelChatroomSuperAdmin 撤销聊天室超级管理员
+func (c *Client) DelChatroomSuperAdmin(ctx context.Context, username string) (res *BaseRes[DelChatroomSuperAdminResData], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/super_admin/%s", username)
+	res = new(BaseRes[DelChatroomSuperAdminResData])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetChatroomSuperAdmins 分页获取聊天室超级管理员列表, pageNum 从1开始
+func (c *Client) GetChatroomSuperAdmins(ctx context.Context, pageNum, pageSize int) (res *BaseRes[[]string], err error) {
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	} else if pageSize > 1000 {
+		pageSize = 1000
+	}
+	params := map[string]any{"pagenum": pageNum, "pagesize": pageSize}
+	res = new(BaseRes[[]string])
+	if err = c.doReq(ctx, http.MethodGet, "chatrooms/super_admin", params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
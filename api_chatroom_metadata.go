@@ -0,0 +1,92 @@
+package easemob_server_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ChatroomMetadataResult 设置或删除聊天室自定义属性的结果
+type ChatroomMetadataResult struct {
+	SuccessKeys []string          `json:"successKeys"` // 操作成功的属性 key 列表
+	ErrorKeys   map[string]string `json:"errorKeys"`   // 操作失败的结果,key为操作失败的属性 key,value为失败原因
+}
+
+// setChatroomMetadata 设置聊天室自定义属性, forced 为 true 时覆盖其他用户设置的属性
+func (c *Client) setChatroomMetadata(ctx context.Context, chatroomId, username string, metadata map[string]string, autoDelete, forced bool) (res *BaseRes[ChatroomMetadataResult], err error) {
+	if len(metadata) == 0 {
+		return nil, errors.New("metadata is empty")
+	} else if len(metadata) > 10 {
+		return nil, errors.New("too many metadata, maximum count is 10")
+	}
+	data := map[string]any{"metaData": metadata, "autoDelete": "NO_DELETE"}
+	if autoDelete {
+		data["autoDelete"] = "DELETE"
+	}
+	pathSuffix := fmt.Sprintf("metadata/chatroom/%s/user/%s", chatroomId, username)
+	if forced {
+		pathSuffix += "/forced"
+	}
+	res = new(BaseRes[ChatroomMetadataResult])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// SetChatroomMetadata 以指定用户的身份设置聊天室自定义属性, 一次最多设置10个
+// 已被其他用户设置的属性不会被覆盖, 会出现在失败结果中; autoDelete 为 true 时该用户退出聊天室后自动删除这些属性
+func (c *Client) SetChatroomMetadata(ctx context.Context, chatroomId, username string, metadata map[string]string, autoDelete bool) (res *BaseRes[ChatroomMetadataResult], err error) {
+	return c.setChatroomMetadata(ctx, chatroomId, username, metadata, autoDelete, false)
+}
+
+// ForceSetChatroomMetadata 以指定用户的身份强制设置聊天室自定义属性, 一次最多设置10个
+// 会覆盖其他用户设置的同名属性; autoDelete 为 true 时该用户退出聊天室后自动删除这些属性
+func (c *Client) ForceSetChatroomMetadata(ctx context.Context, chatroomId, username string, metadata map[string]string, autoDelete bool) (res *BaseRes[ChatroomMetadataResult], err error) {
+	return c.setChatroomMetadata(ctx, chatroomId, username, metadata, autoDelete, true)
+}
+
+// GetChatroomMetadata 获取聊天室自定义属性, keys 为空时获取所有属性
+func (c *Client) GetChatroomMetadata(ctx context.Context, chatroomId string, keys []string) (res *BaseRes[map[string]string], err error) {
+	var data any
+	if len(keys) > 0 {
+		data = map[string]any{"keys": keys}
+	}
+	pathSuffix := fmt.Sprintf("metadata/chatroom/%s", chatroomId)
+	res = new(BaseRes[map[string]string])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// delChatroomMetadata 删除聊天室自定义属性, forced 为 true 时可删除其他用户设置的属性
+func (c *Client) delChatroomMetadata(ctx context.Context, chatroomId, username string, keys []string, forced bool) (res *BaseRes[ChatroomMetadataResult], err error) {
+	if len(keys) == 0 {
+		return nil, errors.New("keys is empty")
+	} else if len(keys) > 10 {
+		return nil, errors.New("too many key, maximum count is 10")
+	}
+	data := map[string]any{"keys": keys}
+	pathSuffix := fmt.Sprintf("metadata/chatroom/%s/user/%s", chatroomId, username)
+	if forced {
+		pathSuffix += "/forced"
+	}
+	res = new(BaseRes[ChatroomMetadataResult])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DelChatroomMetadata 以指定用户的身份删除聊天室自定义属性, 一次最多删除10个
+// 只能删除该用户设置的属性, 其他用户设置的属性会出现在失败结果中
+func (c *Client) DelChatroomMetadata(ctx context.Context, chatroomId, username string, keys []string) (res *BaseRes[ChatroomMetadataResult], err error) {
+	return c.delChatroomMetadata(ctx, chatroomId, username, keys, false)
+}
+
+// ForceDelChatroomMetadata 以指定用户的身份强制删除聊天室自定义属性, 一次最多删除10个, 可删除其他用户设置的属性
+func (c *Client) ForceDelChatroomMetadata(ctx context.Context, chatroomId, username string, keys []string) (res *BaseRes[ChatroomMetadataResult], err error) {
+	return c.delChatroomMetadata(ctx, chatroomId, username, keys, true)
+}
@@ -0,0 +1,170 @@
+package easemob_server_go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MuteChatroomMember 禁言聊天室成员, 一次最多禁言60个
+// duration 为禁言时长, <= 0 时为永久禁言
+func (c *Client) MuteChatroomMember(ctx context.Context, chatroomId string, usernames []string, duration time.Duration) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	muteDuration := duration.Milliseconds()
+	if duration <= 0 {
+		muteDuration = -1
+	}
+	data := map[string]any{"usernames": usernames, "mute_duration": muteDuration}
+	pathSuffix := fmt.Sprintf("chatrooms/%s/mute", chatroomId)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, data, true)
+}
+
+// UnmuteChatroomMember 解除聊天室成员禁言, 一次最多解除60个
+func (c *Client) UnmuteChatroomMember(ctx context.Context, chatroomId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	pathSuffix := fmt.Sprintf("chatrooms/%s/mute/%s", chatroomId, strings.Join(usernames, ","))
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, true)
+}
+
+// GetChatroomMuteList 获取聊天室禁言列表
+func (c *Client) GetChatroomMuteList(ctx context.Context, chatroomId string) (res *BaseRes[[]MuteMember], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/mute", chatroomId)
+	res = new(BaseRes[[]MuteMember])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// MuteAllChatroomMember 开启聊天室全员禁言, 聊天室所有者、管理员及白名单成员不受影响
+func (c *Client) MuteAllChatroomMember(ctx context.Context, chatroomId string) (res *BaseRes[MuteAllResData], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/ban", chatroomId)
+	res = new(BaseRes[MuteAllResData])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// UnmuteAllChatroomMember 解除聊天室全员禁言
+func (c *Client) UnmuteAllChatroomMember(ctx context.Context, chatroomId string) (res *BaseRes[MuteAllResData], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/ban", chatroomId)
+	res = new(BaseRes[MuteAllResData])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// AddChatroomBlock 将单个用户加入聊天室黑名单, 用户会被移出聊天室且无法再次加入
+func (c *Client) AddChatroomBlock(ctx context.Context, chatroomId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/blocks/users/%s", chatroomId, username)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, nil, false)
+}
+
+// BatchAddChatroomBlock 批量将用户加入聊天室黑名单, 一次最多60个
+func (c *Client) BatchAddChatroomBlock(ctx context.Context, chatroomId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	data := map[string]any{"usernames": usernames}
+	pathSuffix := fmt.Sprintf("chatrooms/%s/blocks/users", chatroomId)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, data, true)
+}
+
+// DelChatroomBlock 将单个用户移出聊天室黑名单
+func (c *Client) DelChatroomBlock(ctx context.Context, chatroomId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/blocks/users/%s", chatroomId, username)
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, false)
+}
+
+// BatchDelChatroomBlock 批量将用户移出聊天室黑名单, 一次最多60个
+func (c *Client) BatchDelChatroomBlock(ctx context.Context, chatroomId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	pathSuffix := fmt.Sprintf("chatrooms/%s/blocks/users/%s", chatroomId, strings.Join(usernames, ","))
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, true)
+}
+
+// GetChatroomBlocks 获取聊天室黑名单
+func (c *Client) GetChatroomBlocks(ctx context.Context, chatroomId string) (res *BaseRes[[]string], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/blocks/users", chatroomId)
+	res = new(BaseRes[[]string])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// AddChatroomAllow 将单个成员加入聊天室白名单, 白名单成员不受全员禁言影响
+func (c *Client) AddChatroomAllow(ctx context.Context, chatroomId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/white/users/%s", chatroomId, username)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, nil, false)
+}
+
+// BatchAddChatroomAllow 批量将成员加入聊天室白名单, 一次最多60个
+func (c *Client) BatchAddChatroomAllow(ctx context.Context, chatroomId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	data := map[string]any{"usernames": usernames}
+	pathSuffix := fmt.Sprintf("chatrooms/%s/white/users", chatroomId)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, data, true)
+}
+
+// DelChatroomAllow 将单个成员移出聊天室白名单
+func (c *Client) DelChatroomAllow(ctx context.Context, chatroomId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/white/users/%s", chatroomId, username)
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, false)
+}
+
+// BatchDelChatroomAllow 批量将成员移出聊天室白名单, 一次最多60个
+func (c *Client) BatchDelChatroomAllow(ctx context.Context, chatroomId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	pathSuffix := fmt.Sprintf("chatrooms/%s/white/users/%s", chatroomId, strings.Join(usernames, ","))
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, true)
+}
+
+// GetChatroomAllows 获取聊天室白名单
+func (c *Client) GetChatroomAllows(ctx context.Context, chatroomId string) (res *BaseRes[[]string], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/white/users", chatroomId)
+	res = new(BaseRes[[]string])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type ChatroomAnnouncement struct {
+	Announcement string `json:"announcement"` // 聊天室公告内容
+}
+
+// GetChatroomAnnouncement 获取聊天室公告
+func (c *Client) GetChatroomAnnouncement(ctx context.Context, chatroomId string) (res *BaseRes[ChatroomAnnouncement], err error) {
+	pathSuffix := fmt.Sprintf("chatrooms/%s/announcement", chatroomId)
+	res = new(BaseRes[ChatroomAnnouncement])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// SetChatroomAnnouncement 设置聊天室公告, 最大长度为512字符
+func (c *Client) SetChatroomAnnouncement(ctx context.Context, chatroomId, announcement string) (res *BaseRes[SetAnnouncementResData], err error) {
+	data := map[string]any{"announcement": announcement}
+	pathSuffix := fmt.Sprintf("chatrooms/%s/announcement", chatroomId)
+	res = new(BaseRes[SetAnnouncementResData])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,147 @@
+package easemob_server_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Conversation 用户的服务端会话
+type Conversation struct {
+	ChannelId   string          `json:"channel_id"`   // 会话 ID, 单聊为 {appkey}_{username}@easemob.com, 群聊为 {appkey}_{groupId}@conference.easemob.com
+	UnreadNum   int             `json:"unread_num"`   // 未读消息数
+	LastMessage *HistoryMessage `json:"last_message"` // 会话的最新消息, 会话没有消息时为 nil
+	UpdateTime  int64           `json:"update_time"`  // 会话更新时间, Unix 时间戳, 单位为毫秒
+	Pinned      bool            `json:"pinned"`       // 是否已置顶
+	PinnedTime  int64           `json:"pinned_time"`  // 置顶时间, Unix 时间戳, 单位为毫秒
+}
+
+func (c *Conversation) UnmarshalJSON(data []byte) (err error) {
+	type conversation Conversation
+	var tmp struct {
+		conversation
+		LastMessage string `json:"last_message"` // 最新消息为 JSON 字符串
+	}
+	if err = json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*c = Conversation(tmp.conversation)
+	if len(tmp.LastMessage) > 0 {
+		c.LastMessage = new(HistoryMessage)
+		if err = json.Unmarshal([]byte(tmp.LastMessage), c.LastMessage); err != nil {
+			return err
+		}
+	}
+	return
+}
+
+// ConversationId 会话对方的用户名或群组 ID
+func (c *Conversation) ConversationId() string {
+	id, _, _ := strings.Cut(c.ChannelId, "@")
+	if _, after, found := strings.Cut(id, "_"); found {
+		return after
+	}
+	return id
+}
+
+// ChatType 会话类型, 单聊为 ChatTypeChat, 群聊为 ChatTypeGroupChat
+func (c *Conversation) ChatType() ChatType {
+	if strings.HasSuffix(c.ChannelId, "@conference.easemob.com") {
+		return ChatTypeGroupChat
+	}
+	return ChatTypeChat
+}
+
+// ListConversations 分页获取用户的会话列表, 按会话更新时间倒序
+func (c *Client) ListConversations(ctx context.Context, username string, limit int, cursor string) (res *PageRes[[]Conversation], err error) {
+	if limit <= 0 {
+		limit = 10
+	} else if limit > 50 {
+		limit = 50
+	}
+	params := map[string]any{"limit": limit, "cursor": cursor}
+	pathSuffix := fmt.Sprintf("user/%s/user_channels", username)
+	resTmp := new(BaseRes[struct {
+		ChannelInfos []Conversation `json:"channel_infos"`
+		Cursor       string         `json:"cursor"`
+	}])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, resTmp); err != nil {
+		return nil, err
+	}
+	res = &PageRes[[]Conversation]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration,
+		Cursor: resTmp.Data.Cursor, Data: resTmp.Data.ChannelInfos}
+	return
+}
+
+// ConversationKey 会话标识
+type ConversationKey struct {
+	Id   string   `json:"id"`   // 单聊为对方用户名, 群聊为群组 ID
+	Type ChatType `json:"type"` // 会话类型, ChatTypeChat 或 ChatTypeGroupChat
+}
+
+func checkConversationKeys(keys []ConversationKey) error {
+	if len(keys) == 0 {
+		return errors.New("conversations is empty")
+	} else if len(keys) > 20 {
+		return errors.New("too many conversation, maximum count is 20")
+	}
+	for _, key := range keys {
+		if len(key.Id) == 0 {
+			return errors.New("conversation id is empty")
+		} else if key.Type != ChatTypeChat && key.Type != ChatTypeGroupChat {
+			return fmt.Errorf("unsupported conversation type %s", key.Type)
+		}
+	}
+	return nil
+}
+
+// PinConversation 置顶或取消置顶用户的会话, pinned 为 false 时取消置顶
+func (c *Client) PinConversation(ctx context.Context, username string, conversation ConversationKey, pinned bool) (res *BaseRes[string], err error) {
+	if err = checkConversationKeys([]ConversationKey{conversation}); err != nil {
+		return nil, err
+	}
+	data := map[string]any{"conversationId": conversation.Id, "conversationType": conversation.Type, "isPinned": pinned}
+	pathSuffix := fmt.Sprintf("user/%s/user_channel/pin", username)
+	res = new(BaseRes[string])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// MarkConversationsRead 将用户的会话标记为已读, 一次最多20个会话
+func (c *Client) MarkConversationsRead(ctx context.Context, username string, conversations []ConversationKey) (res *BaseRes[string], err error) {
+	if err = checkConversationKeys(conversations); err != nil {
+		return nil, err
+	}
+	data := map[string]any{"channels": conversations}
+	pathSuffix := fmt.Sprintf("user/%s/user_channel/read", username)
+	res = new(BaseRes[string])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ConversationUnread 会话的未读消息数
+type ConversationUnread struct {
+	ConversationKey
+	UnreadNum int `json:"unread_num"` // 未读消息数
+}
+
+// GetConversationsUnread 获取用户指定会话的未读消息数, 一次最多20个会话
+func (c *Client) GetConversationsUnread(ctx context.Context, username string, conversations []ConversationKey) (res *BaseRes[[]ConversationUnread], err error) {
+	if err = checkConversationKeys(conversations); err != nil {
+		return nil, err
+	}
+	data := map[string]any{"channels": conversations}
+	pathSuffix := fmt.Sprintf("user/%s/user_channels/unread", username)
+	res = new(BaseRes[[]ConversationUnread])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
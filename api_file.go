@@ -0,0 +1,164 @@
+package easemob_server_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/imroc/req/v3"
+)
+
+// ProgressFunc 文件传输进度回调, transferred 为已传输的字节数, total 未知时为 -1
+type ProgressFunc func(transferred, total int64)
+
+type progressReader struct {
+	reader      io.Reader
+	transferred int64
+	total       int64
+	progress    ProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	if n > 0 {
+		r.transferred += int64(n)
+		r.progress(r.transferred, r.total)
+	}
+	return
+}
+
+type progressWriter struct {
+	writer      io.Writer
+	transferred int64
+	total       int64
+	progress    ProgressFunc
+}
+
+func (w *progressWriter) Write(p []byte) (n int, err error) {
+	n, err = w.writer.Write(p)
+	if n > 0 {
+		w.transferred += int64(n)
+		w.progress(w.transferred, w.total)
+	}
+	return
+}
+
+// readerSize 获取 reader 剩余的字节数, 无法获取时返回 -1
+func readerSize(reader io.Reader) int64 {
+	if r, ok := reader.(interface{ Len() int }); ok {
+		return int64(r.Len())
+	}
+	if seeker, ok := reader.(io.Seeker); ok {
+		if current, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			if end, err := seeker.Seek(0, io.SeekEnd); err == nil {
+				if _, err = seeker.Seek(current, io.SeekStart); err == nil {
+					return end - current
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// replayableBody 返回每次发送请求时使用的 reader, 仅当 reader 实现 io.Seeker 时支持重新发送
+// 返回的 reader 不实现 io.Closer, reader 始终由调用方关闭
+func replayableBody(reader io.Reader) func() (io.Reader, error) {
+	seeker, seekable := reader.(io.Seeker)
+	var start int64
+	if seekable {
+		start, _ = seeker.Seek(0, io.SeekCurrent)
+	}
+	sent := false
+	return func() (io.Reader, error) {
+		if !sent {
+			sent = true
+			return io.NopCloser(reader), nil
+		} else if !seekable {
+			return nil, errors.New("reader is not seekable, unable to resend request")
+		}
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(reader), nil
+	}
+}
+
+type ChatFileEntity struct {
+	Uuid        string `json:"uuid"`         // 文件 ID, 发送消息时作为 URL 的一部分
+	Type        string `json:"type"`         // 文件类型, 固定为 chatfile
+	ShareSecret string `json:"share-secret"` // 文件访问密钥, 发送消息时作为 secret 字段
+}
+
+// UploadFile 上传文件, 文件内容以流的方式发送, 不会全部读入内存
+// restrictAccess 为 true 时下载文件需提供 share-secret; progress 可为 nil
+// 仅当 reader 实现 io.Seeker 时, AppToken 失效后才能自动重新上传; reader 由调用方关闭
+// 上传不受 WithTimeout 限制, 需通过 ctx 控制超时
+func (c *Client) UploadFile(ctx context.Context, reader io.Reader, filename string, restrictAccess bool, progress ProgressFunc) (res *UserBaseRes[[]ChatFileEntity], err error) {
+	headers := map[string]string{}
+	if restrictAccess {
+		headers["restrict-access"] = "true"
+	}
+	res = new(UserBaseRes[[]ChatFileEntity])
+	if err = c.uploadFile(ctx, "chatfiles", headers, reader, filename, progress, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DownloadFile 下载文件并写入 writer, 文件内容以流的方式写入, 不会全部读入内存
+// shareSecret 为上传时返回的 share-secret, 未限制访问的文件可为空
+// thumbnail 为 true 时下载图片或视频的缩略图; progress 可为 nil
+// 下载不受 WithTimeout 限制, 需通过 ctx 控制超时
+func (c *Client) DownloadFile(ctx context.Context, uuid, shareSecret string, writer io.Writer, thumbnail bool, progress ProgressFunc) (err error) {
+	headers := map[string]string{}
+	if len(shareSecret) > 0 {
+		headers["share-secret"] = shareSecret
+	}
+	if thumbnail {
+		headers["thumbnail"] = "true"
+	}
+	pathSuffix := fmt.Sprintf("chatfiles/%s", uuid)
+	return c.downloadFile(ctx, pathSuffix, headers, writer, progress)
+}
+
+// uploadFile 以 multipart 表单的 file 字段流式上传文件
+func (c *Client) uploadFile(ctx context.Context, pathSuffix string, headers map[string]string, reader io.Reader, filename string, progress ProgressFunc, res any) (err error) {
+	if reader == nil {
+		return errors.New("reader is nil")
+	}
+	total, nextBody := readerSize(reader), replayableBody(reader)
+	return c.doAuthReq(ctx, c.transferClient, res, func(r *req.Request) (*req.Response, error) {
+		body, err := nextBody()
+		if err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			body = &progressReader{reader: body, total: total, progress: progress}
+		}
+		return r.SetHeaders(headers).SetRetryCount(0).SetFileReader("file", filename, body).Post(pathSuffix)
+	})
+}
+
+// downloadFile 流式下载文件并写入 writer
+func (c *Client) downloadFile(ctx context.Context, pathSuffix string, headers map[string]string, writer io.Writer, progress ProgressFunc) (err error) {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+	resp, err := c.sendAuthReq(ctx, c.transferClient, func(r *req.Request) (*req.Response, error) {
+		return r.SetHeader("Accept", "application/octet-stream").SetHeaders(headers).DisableAutoReadResponse().Get(pathSuffix)
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return c.parseResponse(resp, nil)
+	}
+	if progress != nil {
+		writer = &progressWriter{writer: writer, total: resp.ContentLength, progress: progress}
+	}
+	_, err = io.Copy(writer, resp.Body)
+	return
+}
@@ -0,0 +1,90 @@
+package easemob_server_go
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUploadFileRetryKeepsReaderOpen(t *testing.T) {
+	var uploads atomic.Int32
+	var received []byte
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/app/token":
+			_, _ = w.Write([]byte(`{"access_token":"token","expires_in":3600000}`))
+		case "/org/app/chatfiles":
+			if uploads.Add(1) == 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			received, _ = io.ReadAll(file)
+			_, _ = w.Write([]byte(`{"entities":[{"uuid":"file-1"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}), WithTTLInMillisecond())
+	c.SetAppToken("revoked")
+
+	content := bytes.Repeat([]byte("easemob"), 1024)
+	name := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(name, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	res, err := c.UploadFile(context.Background(), file, "file.txt", false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Entities) != 1 || res.Entities[0].Uuid != "file-1" {
+		t.Fatalf("unexpected response %+v", res)
+	}
+	if !bytes.Equal(received, content) {
+		t.Fatalf("retried upload sent %d bytes, want %d", len(received), len(content))
+	}
+	// reader 由调用方关闭, 上传后仍可使用
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("reader closed by upload: %v", err)
+	}
+}
+
+func TestDownloadFileIgnoresClientTimeout(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		for i := 0; i < 5; i++ {
+			_, _ = w.Write([]byte("chunk"))
+			w.(http.Flusher).Flush()
+			time.Sleep(50 * time.Millisecond)
+		}
+	}), WithTimeout(100*time.Millisecond))
+
+	var buf bytes.Buffer
+	if err := c.DownloadFile(context.Background(), "file-1", "", &buf, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != strings.Repeat("chunk", 5) {
+		t.Fatalf("unexpected content %q", buf.String())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := c.DownloadFile(ctx, "file-1", "", io.Discard, false, nil); err == nil {
+		t.Fatal("expected download to stop at ctx deadline")
+	}
+}
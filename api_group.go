@@ -0,0 +1,438 @@
+package easemob_server_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GroupRole 群组成员角色
+type GroupRole string
+
+const (
+	GroupRoleOwner  GroupRole = "owner"  // 群主
+	GroupRoleAdmin  GroupRole = "admin"  // 群管理员
+	GroupRoleMember GroupRole = "member" // 普通成员
+)
+
+// GroupAffiliation 群组成员及其角色
+type GroupAffiliation struct {
+	Role     GroupRole `json:"role"`
+	Username string    `json:"username"`
+}
+
+// UnmarshalJSON 环信返回的成员格式为 {"owner":"user1"} 或 {"member":"user2"}
+func (a *GroupAffiliation) UnmarshalJSON(data []byte) (err error) {
+	var item map[GroupRole]string
+	if err = json.Unmarshal(data, &item); err != nil {
+		return err
+	}
+	for role, username := range item {
+		a.Role, a.Username = role, username
+	}
+	return
+}
+
+type NewGroup struct {
+	GroupName         string   `json:"groupname"`                     // 群组名称, 最大长度为128字符
+	Description       string   `json:"description,omitempty"`         // 群组描述, 最大长度为512字符
+	Public            bool     `json:"public"`                        // 是否为公开群
+	MaxUsers          int      `json:"maxusers,omitempty"`            // 群组最大成员数(包括群主), 默认为200
+	AllowInvites      bool     `json:"allowinvites"`                  // 是否允许普通成员邀请他人入群, 仅私有群有效
+	MembersOnly       bool     `json:"membersonly"`                   // 用户申请入群是否需要群主或管理员审批
+	InviteNeedConfirm bool     `json:"invite_need_confirm,omitempty"` // 邀请入群时是否需要受邀用户确认
+	Owner             string   `json:"owner"`                         // 群主用户名
+	Members           []string `json:"members,omitempty"`             // 群成员用户名, 不包括群主, 最多100个
+	Custom            string   `json:"custom,omitempty"`              // 群组扩展信息, 最大长度为8KB
+}
+
+type CreateGroupResData struct {
+	GroupId string `json:"groupid"`
+}
+
+// CreateGroup 创建群组
+func (c *Client) CreateGroup(ctx context.Context, group NewGroup) (res *BaseRes[CreateGroupResData], err error) {
+	if len(group.GroupName) == 0 || len(group.Owner) == 0 {
+		return nil, errors.New("groupname or owner is empty")
+	} else if len(group.Members) > 100 {
+		return nil, errors.New("maximum count of member is 100")
+	}
+	res = new(BaseRes[CreateGroupResData])
+	if err = c.doReq(ctx, http.MethodPost, "chatgroups", nil, group, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GroupInfo 群组详情
+type GroupInfo struct {
+	Id                string             `json:"id"`                 // 群组 ID
+	Name              string             `json:"name"`               // 群组名称
+	Description       string             `json:"description"`        // 群组描述
+	Public            bool               `json:"public"`             // 是否为公开群
+	MembersOnly       bool               `json:"membersonly"`        // 用户申请入群是否需要审批
+	AllowInvites      bool               `json:"allowinvites"`       // 是否允许普通成员邀请他人入群
+	MaxUsers          int                `json:"maxusers"`           // 群组最大成员数
+	Owner             string             `json:"owner"`              // 群主用户名
+	Created           int64              `json:"created"`            // 创建时间, Unix 时间戳, 单位为毫秒
+	Custom            string             `json:"custom"`             // 群组扩展信息
+	Mute              bool               `json:"mute"`               // 是否已开启全员禁言
+	Disabled          bool               `json:"disabled"`           // 群组是否已被禁用
+	AffiliationsCount int                `json:"affiliations_count"` // 群成员数(包括群主)
+	Affiliations      []GroupAffiliation `json:"affiliations"`       // 群成员列表
+}
+
+// GetGroup 获取群组详情, 一次最多获取100个群组
+func (c *Client) GetGroup(ctx context.Context, groupIds []string) (res *BaseRes[[]GroupInfo], err error) {
+	if len(groupIds) == 0 {
+		return nil, errors.New("minimum count of group is 1")
+	} else if len(groupIds) > 100 {
+		return nil, errors.New("maximum count of group is 100")
+	}
+	pathSuffix := fmt.Sprintf("chatgroups/%s", strings.Join(groupIds, ","))
+	res = new(BaseRes[[]GroupInfo])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// EditGroup 修改群组信息, 为 nil 的字段不修改
+type EditGroup struct {
+	GroupName         *string `json:"groupname,omitempty"`           // 群组名称
+	Description       *string `json:"description,omitempty"`         // 群组描述
+	MaxUsers          *int    `json:"maxusers,omitempty"`            // 群组最大成员数
+	Public            *bool   `json:"public,omitempty"`              // 是否为公开群
+	MembersOnly       *bool   `json:"membersonly,omitempty"`         // 用户申请入群是否需要审批
+	AllowInvites      *bool   `json:"allowinvites,omitempty"`        // 是否允许普通成员邀请他人入群
+	InviteNeedConfirm *bool   `json:"invite_need_confirm,omitempty"` // 邀请入群时是否需要受邀用户确认
+	Custom            *string `json:"custom,omitempty"`              // 群组扩展信息
+}
+
+// UpdateGroup 修改群组信息
+// 返回结果 Data 的 key 为修改的字段名, value 为是否修改成功
+func (c *Client) UpdateGroup(ctx context.Context, groupId string, group EditGroup) (res *BaseRes[map[string]bool], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s", groupId)
+	res = new(BaseRes[map[string]bool])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, group, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type TransferGroupOwnerResData struct {
+	NewOwner bool `json:"newowner"` // 是否转让成功
+}
+
+// TransferGroupOwner 转让群组
+func (c *Client) TransferGroupOwner(ctx context.Context, groupId, newOwner string) (res *BaseRes[TransferGroupOwnerResData], err error) {
+	data := map[string]any{"newowner": newOwner}
+	pathSuffix := fmt.Sprintf("chatgroups/%s", groupId)
+	res = new(BaseRes[TransferGroupOwnerResData])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type DeleteGroupResData struct {
+	Success bool   `json:"success"`
+	GroupId string `json:"groupid"`
+}
+
+// DeleteGroup 解散群组
+func (c *Client) DeleteGroup(ctx context.Context, groupId string) (res *BaseRes[DeleteGroupResData], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s", groupId)
+	res = new(BaseRes[DeleteGroupResData])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GroupListItem 群组列表项
+type GroupListItem struct {
+	GroupId      string `json:"groupid"`       // 群组 ID
+	GroupName    string `json:"groupname"`     // 群组名称
+	Owner        string `json:"owner"`         // 群主, 格式为 {appKey}_{username}
+	Affiliations int    `json:"affiliations"`  // 群成员数(包括群主)
+	Type         string `json:"type"`          // 固定为 group
+	LastModified string `json:"last_modified"` // 最近一次修改的时间, Unix 时间戳, 单位为毫秒
+}
+
+// ListGroups 分页获取 App 下的群组
+func (c *Client) ListGroups(ctx context.Context, limit int, cursor string) (res *PageRes[[]GroupListItem], err error) {
+	if limit <= 0 {
+		limit = 10
+	} else if limit > 100 {
+		limit = 100
+	}
+	params := map[string]any{"limit": limit, "cursor": cursor}
+	res = new(PageRes[[]GroupListItem])
+	if err = c.doReq(ctx, http.MethodGet, "chatgroups", params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type UserJoinedGroup struct {
+	GroupId   string `json:"groupid"`   // 群组 ID
+	GroupName string `json:"groupname"` // 群组名称
+}
+
+// ListUserJoinedGroups 分页获取用户加入的群组, pageNum 从1开始
+func (c *Client) ListUserJoinedGroups(ctx context.Context, username string, pageNum, pageSize int) (res *BaseRes[[]UserJoinedGroup], err error) {
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	} else if pageSize > 20 {
+		pageSize = 20
+	}
+	params := map[string]any{"pagenum": pageNum, "pagesize": pageSize}
+	pathSuffix := fmt.Sprintf("users/%s/joined_chatgroups", username)
+	res = new(BaseRes[[]UserJoinedGroup])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetGroupMembers 分页获取群组成员及其角色, pageNum 从1开始
+func (c *Client) GetGroupMembers(ctx context.Context, groupId string, pageNum, pageSize int) (res *BaseRes[[]GroupAffiliation], err error) {
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	} else if pageSize > 1000 {
+		pageSize = 1000
+	}
+	params := map[string]any{"pagenum": pageNum, "pagesize": pageSize}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/users", groupId)
+	res = new(BaseRes[[]GroupAffiliation])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// EditGroupMemberResult 编辑群组成员结果
+type EditGroupMemberResult struct {
+	Success []string          `json:"success"` // 操作成功的用户列表
+	Fail    map[string]string `json:"fail"`    // 操作失败的结果,key为操作失败的用户名,value为失败原因
+}
+
+// groupMemberResult 群组成员操作的单个用户结果
+type groupMemberResult struct {
+	Result bool   `json:"result"`
+	Reason string `json:"reason"`
+	User   string `json:"user"`
+}
+
+func newEditGroupMemberResult(items ...groupMemberResult) (result EditGroupMemberResult) {
+	result.Success, result.Fail = make([]string, 0, len(items)), make(map[string]string)
+	for _, item := range items {
+		if item.Result {
+			result.Success = append(result.Success, item.User)
+		} else {
+			result.Fail[item.User] = item.Reason
+		}
+	}
+	return
+}
+
+// AddGroupMember 添加单个群组成员
+func (c *Client) AddGroupMember(ctx context.Context, groupId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/users/%s", groupId, username)
+	resTmp := new(BaseRes[groupMemberResult])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, nil, resTmp); err != nil {
+		return nil, err
+	}
+	res = &BaseRes[EditGroupMemberResult]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration}
+	res.Data = newEditGroupMemberResult(resTmp.Data)
+	return
+}
+
+// BatchAddGroupMember 批量添加群组成员, 一次最多添加60个
+// 已在群组中或不存在的用户不会出现在添加成功的列表中
+func (c *Client) BatchAddGroupMember(ctx context.Context, groupId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if len(usernames) == 0 {
+		return nil, errors.New("usernames is empty")
+	} else if len(usernames) > 60 {
+		return nil, errors.New("too many username, maximum count is 60")
+	}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/users", groupId)
+	return c.batchAddMember(ctx, pathSuffix, usernames)
+}
+
+// batchAddMember 批量添加群组或聊天室成员, 根据返回的新成员列表计算每个用户的添加结果
+func (c *Client) batchAddMember(ctx context.Context, pathSuffix string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	data := map[string]any{"usernames": usernames}
+	resTmp := new(BaseRes[struct {
+		NewMembers []string `json:"newmembers"`
+	}])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, data, resTmp); err != nil {
+		return nil, err
+	}
+	res = &BaseRes[EditGroupMemberResult]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration}
+	added := make(map[string]bool, len(resTmp.Data.NewMembers))
+	for _, username := range resTmp.Data.NewMembers {
+		added[username] = true
+	}
+	items := make([]groupMemberResult, 0, len(usernames))
+	for _, username := range usernames {
+		items = append(items, groupMemberResult{Result: added[username], Reason: "not added", User: username})
+	}
+	res.Data = newEditGroupMemberResult(items...)
+	return
+}
+
+// DelGroupMember 移除单个群组成员
+func (c *Client) DelGroupMember(ctx context.Context, groupId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/users/%s", groupId, username)
+	resTmp := new(BaseRes[groupMemberResult])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, resTmp); err != nil {
+		return nil, err
+	}
+	res = &BaseRes[EditGroupMemberResult]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration}
+	res.Data = newEditGroupMemberResult(resTmp.Data)
+	return
+}
+
+// BatchDelGroupMember 批量移除群组成员, 一次最多移除60个
+func (c *Client) BatchDelGroupMember(ctx context.Context, groupId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if len(usernames) == 0 {
+		return nil, errors.New("usernames is empty")
+	} else if len(usernames) > 60 {
+		return nil, errors.New("too many username, maximum count is 60")
+	}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/users/%s", groupId, strings.Join(usernames, ","))
+	resTmp := new(BaseRes[[]groupMemberResult])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, resTmp); err != nil {
+		return nil, err
+	}
+	res = &BaseRes[EditGroupMemberResult]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration}
+	res.Data = newEditGroupMemberResult(resTmp.Data...)
+	return
+}
+
+// AddGroupAdmin 添加群管理员
+func (c *Client) AddGroupAdmin(ctx context.Context, groupId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	data := map[string]any{"newadmin": username}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/admin", groupId)
+	resTmp := new(BaseRes[struct {
+		Result   string `json:"result"`
+		NewAdmin string `json:"newadmin"`
+	}])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, data, resTmp); err != nil {
+		return nil, err
+	}
+	res = &BaseRes[EditGroupMemberResult]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration}
+	res.Data = newEditGroupMemberResult(groupMemberResult{Result: resTmp.Data.Result == "success", Reason: resTmp.Data.Result, User: username})
+	return
+}
+
+// DelGroupAdmin 移除群管理员
+func (c *Client) DelGroupAdmin(ctx context.Context, groupId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/admin/%s", groupId, username)
+	resTmp := new(BaseRes[struct {
+		Result   string `json:"result"`
+		OldAdmin string `json:"oldadmin"`
+	}])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, resTmp); err != nil {
+		return nil, err
+	}
+	res = &BaseRes[EditGroupMemberResult]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration}
+	res.Data = newEditGroupMemberResult(groupMemberResult{Result: resTmp.Data.Result == "success", Reason: resTmp.Data.Result, User: username})
+	return
+}
+
+// GetGroupAdmins 获取群管理员列表
+func (c *Client) GetGroupAdmins(ctx context.Context, groupId string) (res *BaseRes[[]string], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/admin", groupId)
+	res = new(BaseRes[[]string])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// SetGroupMemberMetadata 设置群成员自定义属性
+func (c *Client) SetGroupMemberMetadata(ctx context.Context, groupId, username string, metadata map[string]string) (res *BaseRes[map[string]string], err error) {
+	if len(metadata) == 0 {
+		return nil, errors.New("metadata is empty")
+	}
+	data := map[string]any{"metaData": metadata}
+	pathSuffix := fmt.Sprintf("metadata/chatgroup/%s/user/%s", groupId, username)
+	res = new(BaseRes[map[string]string])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetGroupMemberMetadata 获取单个群成员的所有自定义属性
+func (c *Client) GetGroupMemberMetadata(ctx context.Context, groupId, username string) (res *BaseRes[map[string]string], err error) {
+	pathSuffix := fmt.Sprintf("metadata/chatgroup/%s/user/%s", groupId, username)
+	res = new(BaseRes[map[string]string])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// BatchGetGroupMemberMetadata 根据属性 key 批量获取群成员的自定义属性, 一次最多获取10个成员
+// properties 为空时获取所有属性
+func (c *Client) BatchGetGroupMemberMetadata(ctx context.Context, groupId string, targets, properties []string) (res *BaseRes[[]UserMetadata], err error) {
+	if len(targets) == 0 {
+		return nil, errors.New("targets is empty")
+	} else if len(targets) > 10 {
+		return nil, errors.New("too many target, maximum count is 10")
+	}
+	data := map[string]any{"targets": targets, "properties": properties}
+	pathSuffix := fmt.Sprintf("metadata/chatgroup/%s/get", groupId)
+	resTmp := new(BaseRes[map[string]map[string]string])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, data, resTmp); err != nil {
+		return nil, err
+	}
+	res = &BaseRes[[]UserMetadata]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration}
+	for username, metadata := range resTmp.Data {
+		res.Data = append(res.Data, UserMetadata{Username: username, Metadata: metadata})
+	}
+	return
+}
+
+type GroupAnnouncement struct {
+	Announcement string `json:"announcement"` // 群公告内容
+}
+
+// GetGroupAnnouncement 获取群公告
+func (c *Client) GetGroupAnnouncement(ctx context.Context, groupId string) (res *BaseRes[GroupAnnouncement], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/announcement", groupId)
+	res = new(BaseRes[GroupAnnouncement])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type SetAnnouncementResData struct {
+	Id     string `json:"id"`     // 群组或聊天室 ID
+	Result bool   `json:"result"` // 是否设置成功
+}
+
+// SetGroupAnnouncement 设置群公告, 最大长度为512字符
+func (c *Client) SetGroupAnnouncement(ctx context.Context, groupId, announcement string) (res *BaseRes[SetAnnouncementResData], err error) {
+	data := map[string]any{"announcement": announcement}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/announcement", groupId)
+	res = new(BaseRes[SetAnnouncementResData])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package easemob_server_go
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// GroupShareFile 群组共享文件
+type GroupShareFile struct {
+	FileId    string `json:"file_id"`    // 文件 ID
+	FileName  string `json:"file_name"`  // 文件名称
+	FileOwner string `json:"file_owner"` // 文件上传者
+	FileSize  int64  `json:"file_size"`  // 文件大小, 单位为字节
+	Created   int64  `json:"created"`    // 上传时间, Unix 时间戳, 单位为毫秒
+}
+
+// GetGroupShareFiles 分页获取群组共享文件, pageNum 从1开始
+func (c *Client) GetGroupShareFiles(ctx context.Context, groupId string, pageNum, pageSize int) (res *BaseRes[[]GroupShareFile], err error) {
+	if pageNum <= 0 {
+		pageNum = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	} else if pageSize > 1000 {
+		pageSize = 1000
+	}
+	params := map[string]any{"pagenum": pageNum, "pagesize": pageSize}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/share_files", groupId)
+	res = new(BaseRes[[]GroupShareFile])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type UploadGroupShareFileResData struct {
+	GroupId  string `json:"group_id"`  // 群组 ID
+	FileId   string `json:"file_id"`   // 文件 ID
+	FileName string `json:"file_name"` // 文件名称
+	FileSize int64  `json:"file_size"` // 文件大小, 单位为字节
+	FileUrl  string `json:"file_url"`  // 文件的 URL 地址
+	Created  int64  `json:"created"`   // 上传时间, Unix 时间戳, 单位为毫秒
+}
+
+// UploadGroupShareFile 上传群组共享文件, 文件内容以流的方式发送, 不会全部读入内存
+// progress 可为 nil; 仅当 reader 实现 io.Seeker 时, AppToken 失效后才能自动重新上传; reader 由调用方关闭
+// 上传不受 WithTimeout 限制, 需通过 ctx 控制超时
+func (c *Client) UploadGroupShareFile(ctx context.Context, groupId string, reader io.Reader, filename string, progress ProgressFunc) (res *BaseRes[UploadGroupShareFileResData], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/share_files", groupId)
+	headers := map[string]string{"restrict-access": "true"}
+	res = new(BaseRes[UploadGroupShareFileResData])
+	if err = c.uploadFile(ctx, pathSuffix, headers, reader, filename, progress, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DownloadGroupShareFile 下载群组共享文件并写入 writer, 文件内容以流的方式写入, 不会全部读入内存
+// progress 可为 nil; 下载不受 WithTimeout 限制, 需通过 ctx 控制超时
+func (c *Client) DownloadGroupShareFile(ctx context.Context, groupId, fileId string, writer io.Writer, progress ProgressFunc) (err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/share_files/%s", groupId, fileId)
+	return c.downloadFile(ctx, pathSuffix, nil, writer, progress)
+}
+
+type DelGroupShareFileResData struct {
+	GroupId string `json:"group_id"` // 群组 ID
+	FileId  string `json:"file_id"`  // 文件 ID
+	Result  bool   `json:"result"`   // 是否删除成功
+}
+
+// DelGroupShareFile 删除群组共享文件
+func (c *Client) DelGroupShareFile(ctx context.Context, groupId, fileId string) (res *BaseRes[DelGroupShareFileResData], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/share_files/%s", groupId, fileId)
+	res = new(BaseRes[DelGroupShareFileResData])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,185 @@
+package easemob_server_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// doGroupMemberReq 执行返回单个用户结果或用户结果列表的群组成员操作
+func (c *Client) doGroupMemberReq(ctx context.Context, method, pathSuffix string, data any, batch bool) (res *BaseRes[EditGroupMemberResult], err error) {
+	if !batch {
+		resTmp := new(BaseRes[groupMemberResult])
+		if err = c.doReq(ctx, method, pathSuffix, nil, data, resTmp); err != nil {
+			return nil, err
+		}
+		res = &BaseRes[EditGroupMemberResult]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration}
+		res.Data = newEditGroupMemberResult(resTmp.Data)
+		return
+	}
+	resTmp := new(BaseRes[[]groupMemberResult])
+	if err = c.doReq(ctx, method, pathSuffix, nil, data, resTmp); err != nil {
+		return nil, err
+	}
+	res = &BaseRes[EditGroupMemberResult]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration}
+	res.Data = newEditGroupMemberResult(resTmp.Data...)
+	return
+}
+
+func checkGroupUsernames(usernames []string) error {
+	if len(usernames) == 0 {
+		return errors.New("usernames is empty")
+	} else if len(usernames) > 60 {
+		return errors.New("too many username, maximum count is 60")
+	}
+	return nil
+}
+
+// MuteGroupMember 禁言群组成员, 一次最多禁言60个
+// duration 为禁言时长, <= 0 时为永久禁言
+func (c *Client) MuteGroupMember(ctx context.Context, groupId string, usernames []string, duration time.Duration) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	muteDuration := duration.Milliseconds()
+	if duration <= 0 {
+		muteDuration = -1
+	}
+	data := map[string]any{"usernames": usernames, "mute_duration": muteDuration}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/mute", groupId)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, data, true)
+}
+
+// UnmuteGroupMember 解除群组成员禁言, 一次最多解除60个
+func (c *Client) UnmuteGroupMember(ctx context.Context, groupId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/mute/%s", groupId, strings.Join(usernames, ","))
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, true)
+}
+
+// MuteMember 被禁言的成员
+type MuteMember struct {
+	User   string `json:"user"`   // 被禁言的用户名
+	Expire int64  `json:"expire"` // 禁言到期时间, Unix 时间戳, 单位为毫秒, 永久禁言时为 -1
+}
+
+// GetGroupMuteList 获取群组禁言列表
+func (c *Client) GetGroupMuteList(ctx context.Context, groupId string) (res *BaseRes[[]MuteMember], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/mute", groupId)
+	res = new(BaseRes[[]MuteMember])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type MuteAllResData struct {
+	Mute bool `json:"mute"` // 当前是否处于全员禁言状态
+}
+
+// MuteAllGroupMember 开启群组全员禁言, 群主及管理员不受影响
+func (c *Client) MuteAllGroupMember(ctx context.Context, groupId string) (res *BaseRes[MuteAllResData], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/ban", groupId)
+	res = new(BaseRes[MuteAllResData])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// UnmuteAllGroupMember 解除群组全员禁言
+func (c *Client) UnmuteAllGroupMember(ctx context.Context, groupId string) (res *BaseRes[MuteAllResData], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/ban", groupId)
+	res = new(BaseRes[MuteAllResData])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// AddGroupBlock 将单个用户加入群组黑名单, 用户会被移出群组且无法再次加入
+func (c *Client) AddGroupBlock(ctx context.Context, groupId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/blocks/users/%s", groupId, username)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, nil, false)
+}
+
+// BatchAddGroupBlock 批量将用户加入群组黑名单, 一次最多60个
+func (c *Client) BatchAddGroupBlock(ctx context.Context, groupId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	data := map[string]any{"usernames": usernames}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/blocks/users", groupId)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, data, true)
+}
+
+// DelGroupBlock 将单个用户移出群组黑名单
+func (c *Client) DelGroupBlock(ctx context.Context, groupId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/blocks/users/%s", groupId, username)
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, false)
+}
+
+// BatchDelGroupBlock 批量将用户移出群组黑名单, 一次最多60个
+func (c *Client) BatchDelGroupBlock(ctx context.Context, groupId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/blocks/users/%s", groupId, strings.Join(usernames, ","))
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, true)
+}
+
+// GetGroupBlocks 获取群组黑名单
+func (c *Client) GetGroupBlocks(ctx context.Context, groupId string) (res *BaseRes[[]string], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/blocks/users", groupId)
+	res = new(BaseRes[[]string])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// AddGroupAllow 将单个成员加入群组白名单, 白名单成员不受全员禁言影响
+func (c *Client) AddGroupAllow(ctx context.Context, groupId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/white/users/%s", groupId, username)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, nil, false)
+}
+
+// BatchAddGroupAllow 批量将成员加入群组白名单, 一次最多60个
+func (c *Client) BatchAddGroupAllow(ctx context.Context, groupId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	data := map[string]any{"usernames": usernames}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/white/users", groupId)
+	return c.doGroupMemberReq(ctx, http.MethodPost, pathSuffix, data, true)
+}
+
+// DelGroupAllow 将单个成员移出群组白名单
+func (c *Client) DelGroupAllow(ctx context.Context, groupId, username string) (res *BaseRes[EditGroupMemberResult], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/white/users/%s", groupId, username)
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, false)
+}
+
+// BatchDelGroupAllow 批量将成员移出群组白名单, 一次最多60个
+func (c *Client) BatchDelGroupAllow(ctx context.Context, groupId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if err = checkGroupUsernames(usernames); err != nil {
+		return nil, err
+	}
+	pathSuffix := fmt.Sprintf("chatgroups/%s/white/users/%s", groupId, strings.Join(usernames, ","))
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, nil, true)
+}
+
+// GetGroupAllows 获取群组白名单
+func (c *Client) GetGroupAllows(ctx context.Context, groupId string) (res *BaseRes[[]string], err error) {
+	pathSuffix := fmt.Sprintf("chatgroups/%s/white/users", groupId)
+	res = new(BaseRes[[]string])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,507 @@
+package easemob_server_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MessageType 消息类型
+type MessageType string
+
+const (
+	MessageTypeText     MessageType = "txt"    // 文本消息
+	MessageTypeImage    MessageType = "img"    // 图片消息
+	MessageTypeAudio    MessageType = "audio"  // 语音消息
+	MessageTypeVideo    MessageType = "video"  // 视频消息
+	MessageTypeFile     MessageType = "file"   // 文件消息
+	MessageTypeLocation MessageType = "loc"    // 位置消息
+	MessageTypeCmd      MessageType = "cmd"    // 透传消息
+	MessageTypeCustom   MessageType = "custom" // 自定义消息
+)
+
+// MessageBody 消息体
+type MessageBody interface {
+	MessageType() MessageType
+}
+
+// TextMessageBody 文本消息
+type TextMessageBody struct {
+	Msg string `json:"msg"` // 消息内容
+}
+
+func (TextMessageBody) MessageType() MessageType { return MessageTypeText }
+
+// ImageSize 图片尺寸, 单位为像素
+type ImageSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// ImageMessageBody 图片消息
+type ImageMessageBody struct {
+	Filename string     `json:"filename"`       // 图片名称
+	Secret   string     `json:"secret"`         // 图片的访问密钥, 即上传文件后返回的 share-secret
+	Url      string     `json:"url"`            // 图片的 URL 地址
+	Size     *ImageSize `json:"size,omitempty"` // 图片尺寸
+}
+
+func (ImageMessageBody) MessageType() MessageType { return MessageTypeImage }
+
+// AudioMessageBody 语音消息
+type AudioMessageBody struct {
+	Filename string `json:"filename"` // 语音文件名称
+	Secret   string `json:"secret"`   // 语音文件的访问密钥, 即上传文件后返回的 share-secret
+	Url      string `json:"url"`      // 语音文件的 URL 地址
+	Length   int    `json:"length"`   // 语音时长, 单位为秒
+}
+
+func (AudioMessageBody) MessageType() MessageType { return MessageTypeAudio }
+
+// VideoMessageBody 视频消息
+type VideoMessageBody struct {
+	Filename    string `json:"filename"`               // 视频文件名称
+	Secret      string `json:"secret"`                 // 视频文件的访问密钥, 即上传文件后返回的 share-secret
+	Url         string `json:"url"`                    // 视频文件的 URL 地址
+	Length      int    `json:"length"`                 // 视频时长, 单位为秒
+	FileLength  int64  `json:"file_length"`            // 视频文件大小, 单位为字节
+	Thumb       string `json:"thumb,omitempty"`        // 视频缩略图的 URL 地址
+	ThumbSecret string `json:"thumb_secret,omitempty"` // 视频缩略图的访问密钥
+}
+
+func (VideoMessageBody) MessageType() MessageType { return MessageTypeVideo }
+
+// FileMessageBody 文件消息
+type FileMessageBody struct {
+	Filename string `json:"filename"` // 文件名称
+	Secret   string `json:"secret"`   // 文件的访问密钥, 即上传文件后返回的 share-secret
+	Url      string `json:"url"`      // 文件的 URL 地址
+}
+
+func (FileMessageBody) MessageType() MessageType { return MessageTypeFile }
+
+// LocationMessageBody 位置消息
+type LocationMessageBody struct {
+	Lat  string `json:"lat"`  // 纬度
+	Lng  string `json:"lng"`  // 经度
+	Addr string `json:"addr"` // 地址
+}
+
+func (LocationMessageBody) MessageType() MessageType { return MessageTypeLocation }
+
+// UnmarshalJSON 历史消息及回调中的经纬度可能为数字或字符串, 统一解析为字符串
+func (b *LocationMessageBody) UnmarshalJSON(data []byte) (err error) {
+	var body struct {
+		Lat  json.RawMessage `json:"lat"`
+		Lng  json.RawMessage `json:"lng"`
+		Addr string          `json:"addr"`
+	}
+	if err = json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+	if b.Lat, err = coordinateString(body.Lat); err != nil {
+		return err
+	}
+	if b.Lng, err = coordinateString(body.Lng); err != nil {
+		return err
+	}
+	b.Addr = body.Addr
+	return
+}
+
+// coordinateString 将数字或字符串形式的经纬度转换为字符串
+func coordinateString(raw json.RawMessage) (coordinate string, err error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	if raw[0] == '"' {
+		err = json.Unmarshal(raw, &coordinate)
+		return
+	}
+	var number json.Number
+	if err = json.Unmarshal(raw, &number); err != nil {
+		return "", err
+	}
+	return number.String(), nil
+}
+
+// CmdMessageBody 透传消息
+type CmdMessageBody struct {
+	Action string `json:"action"` // 命令内容
+}
+
+func (CmdMessageBody) MessageType() MessageType { return MessageTypeCmd }
+
+// CustomMessageBody 自定义消息
+type CustomMessageBody struct {
+	CustomEvent string            `json:"customEvent,omitempty"` // 自定义事件类型
+	CustomExts  map[string]string `json:"customExts,omitempty"`  // 自定义事件属性, 最多16个
+}
+
+func (CustomMessageBody) MessageType() MessageType { return MessageTypeCustom }
+
+// UnknownMessageBody 未知类型的消息, 保留原始内容
+type UnknownMessageBody struct {
+	Type MessageType
+	Raw  json.RawMessage
+}
+
+func (b UnknownMessageBody) MessageType() MessageType { return b.Type }
+
+// MarshalJSON 原样输出原始内容, 原始内容为空时输出空对象
+func (b UnknownMessageBody) MarshalJSON() ([]byte, error) {
+	if len(b.Raw) == 0 {
+		return []byte("{}"), nil
+	}
+	return b.Raw, nil
+}
+
+// decodeMessageBody 根据消息体中的 type 字段解析消息体
+func decodeMessageBody(raw json.RawMessage) (body MessageBody, err error) {
+	var typed struct {
+		Type MessageType `json:"type"`
+	}
+	if err = json.Unmarshal(raw, &typed); err != nil {
+		return nil, err
+	}
+	return decodeTypedMessageBody(typed.Type, raw)
+}
+
+// decodeTypedMessageBody 按指定的消息类型解析消息体
+func decodeTypedMessageBody(msgType MessageType, raw json.RawMessage) (body MessageBody, err error) {
+	switch msgType {
+	case MessageTypeText:
+		return unmarshalMessageBody[TextMessageBody](raw)
+	case MessageTypeImage:
+		return unmarshalMessageBody[ImageMessageBody](raw)
+	case MessageTypeAudio:
+		return unmarshalMessageBody[AudioMessageBody](raw)
+	case MessageTypeVideo:
+		return unmarshalMessageBody[VideoMessageBody](raw)
+	case MessageTypeFile:
+		return unmarshalMessageBody[FileMessageBody](raw)
+	case MessageTypeLocation:
+		return unmarshalMessageBody[LocationMessageBody](raw)
+	case MessageTypeCmd:
+		return unmarshalMessageBody[CmdMessageBody](raw)
+	case MessageTypeCustom:
+		return unmarshalMessageBody[CustomMessageBody](raw)
+	}
+	return UnknownMessageBody{Type: msgType, Raw: raw}, nil
+}
+
+func unmarshalMessageBody[T MessageBody](raw json.RawMessage) (MessageBody, error) {
+	var body T
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// MessagePushOption 消息的离线推送选项, 发送时写入消息扩展字段 ext
+type MessagePushOption struct {
+	Title              string            // 推送标题, 对应 em_apns_ext.em_push_title
+	Content            string            // 推送内容, 对应 em_apns_ext.em_push_content
+	Extern             map[string]any    // 推送自定义扩展, 对应 em_apns_ext.extern
+	IgnoreNotification bool              // 是否为静默消息, 对应 em_ignore_notification
+	ForceNotification  bool              // 是否强制推送, 对应 em_force_notification
+	Template           *PushTemplateArgs // 使用推送模板生成推送的标题及内容, 对应 em_push_template
+}
+
+func (o *MessagePushOption) apply(ext map[string]any) {
+	apnsExt := make(map[string]any)
+	if len(o.Title) > 0 {
+		apnsExt["em_push_title"] = o.Title
+	}
+	if len(o.Content) > 0 {
+		apnsExt["em_push_content"] = o.Content
+	}
+	if len(o.Extern) > 0 {
+		apnsExt["extern"] = o.Extern
+	}
+	if len(apnsExt) > 0 {
+		ext["em_apns_ext"] = apnsExt
+	}
+	if o.IgnoreNotification {
+		ext["em_ignore_notification"] = true
+	}
+	if o.ForceNotification {
+		ext["em_force_notification"] = true
+	}
+	if o.Template != nil {
+		ext["em_push_template"] = o.Template
+	}
+}
+
+// ChatroomMsgLevel 聊天室消息优先级
+type ChatroomMsgLevel string
+
+const (
+	ChatroomMsgLevelHigh   ChatroomMsgLevel = "high"   // 高优先级
+	ChatroomMsgLevelNormal ChatroomMsgLevel = "normal" // 普通优先级
+	ChatroomMsgLevelLow    ChatroomMsgLevel = "low"    // 低优先级
+)
+
+// MessageOption 发送消息的可选参数
+type MessageOption struct {
+	Ext        map[string]any     // 消息扩展字段
+	SyncDevice bool               // 消息发送成功后是否同步给发送方的其他在线设备
+	RouteOnly  bool               // 是否只投递给在线用户, 对应 routetype 为 ROUTE_ONLINE
+	Push       *MessagePushOption // 离线推送选项
+	Users      []string           // 定向消息的接收成员, 仅群组及聊天室消息有效, 最多20个
+	Level      ChatroomMsgLevel   // 消息优先级, 仅聊天室消息有效, 默认为 normal
+}
+
+// buildMessageExt 合并消息扩展字段及离线推送选项
+func buildMessageExt(opt *MessageOption) map[string]any {
+	ext := make(map[string]any, len(opt.Ext))
+	for key, val := range opt.Ext {
+		ext[key] = val
+	}
+	if opt.Push != nil {
+		opt.Push.apply(ext)
+	}
+	return ext
+}
+
+// buildMessageData 构建发送消息的请求体, Users 仅群组及聊天室消息可用, Level 仅聊天室消息可用
+func buildMessageData(chatType ChatType, from string, to []string, body MessageBody, opt *MessageOption) (data map[string]any, err error) {
+	if body == nil {
+		return nil, errors.New("message body is empty")
+	}
+	data = map[string]any{"type": body.MessageType(), "body": body, "to": to}
+	if len(from) > 0 {
+		data["from"] = from
+	}
+	if opt == nil {
+		return
+	}
+	if ext := buildMessageExt(opt); len(ext) > 0 {
+		data["ext"] = ext
+	}
+	if opt.SyncDevice {
+		data["sync_device"] = true
+	}
+	if opt.RouteOnly {
+		data["routetype"] = "ROUTE_ONLINE"
+	}
+	if len(opt.Users) > 0 {
+		if chatType == ChatTypeChat {
+			return nil, errors.New("directed users is only supported by group and chatroom message")
+		} else if len(opt.Users) > 20 {
+			return nil, errors.New("maximum count of directed user is 20")
+		}
+		data["users"] = opt.Users
+	}
+	if len(opt.Level) > 0 {
+		if chatType != ChatTypeChatroom {
+			return nil, errors.New("message level is only supported by chatroom message")
+		}
+		data["chatroom_msg_level"] = opt.Level
+	}
+	return
+}
+
+// SendUserMessage 发送单聊消息
+// from 为空时发送方为 admin; 返回结果 Data 的 key 为接收方用户名, value 为消息 ID
+func (c *Client) SendUserMessage(ctx context.Context, from string, to []string, body MessageBody, opt *MessageOption) (res *BaseRes[map[string]string], err error) {
+	if len(to) == 0 {
+		return nil, errors.New("minimum count of receiver is 1")
+	} else if len(to) > 600 {
+		return nil, errors.New("maximum count of receiver is 600")
+	}
+	data, err := buildMessageData(ChatTypeChat, from, to, body, opt)
+	if err != nil {
+		return nil, err
+	}
+	res = new(BaseRes[map[string]string])
+	if err = c.doReq(ctx, http.MethodPost, "messages/users", nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// SendGroupMessage 发送群聊消息
+// from 为空时发送方为 admin; 返回结果 Data 的 key 为群组 ID, value 为消息 ID
+func (c *Client) SendGroupMessage(ctx context.Context, from string, to []string, body MessageBody, opt *MessageOption) (res *BaseRes[map[string]string], err error) {
+	if len(to) == 0 {
+		return nil, errors.New("minimum count of group is 1")
+	} else if len(to) > 3 {
+		return nil, errors.New("maximum count of group is 3")
+	}
+	data, err := buildMessageData(ChatTypeGroupChat, from, to, body, opt)
+	if err != nil {
+		return nil, err
+	}
+	res = new(BaseRes[map[string]string])
+	if err = c.doReq(ctx, http.MethodPost, "messages/chatgroups", nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// SendChatroomMessage 发送聊天室消息
+// from 为空时发送方为 admin; 返回结果 Data 的 key 为聊天室 ID, value 为消息 ID
+func (c *Client) SendChatroomMessage(ctx context.Context, from string, to []string, body MessageBody, opt *MessageOption) (res *BaseRes[map[string]string], err error) {
+	if len(to) == 0 {
+		return nil, errors.New("minimum count of chatroom is 1")
+	} else if len(to) > 10 {
+		return nil, errors.New("maximum count of chatroom is 10")
+	}
+	data, err := buildMessageData(ChatTypeChatroom, from, to, body, opt)
+	if err != nil {
+		return nil, err
+	}
+	res = new(BaseRes[map[string]string])
+	if err = c.doReq(ctx, http.MethodPost, "messages/chatrooms", nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type ChatroomBroadcastResData struct {
+	Id string `json:"id"` // 广播消息 ID
+}
+
+// SendChatroomBroadcast 向 App 下所有活跃聊天室发送广播消息
+// from 为空时发送方为 admin; opt 中仅 Ext、Push 及 Level 对广播消息有效, 设置 Users 时返回错误
+func (c *Client) SendChatroomBroadcast(ctx context.Context, from string, body MessageBody, opt *MessageOption) (res *BaseRes[ChatroomBroadcastResData], err error) {
+	if body == nil {
+		return nil, errors.New("message body is empty")
+	}
+	// 广播接口的消息类型在 msg 内部
+	msg, err := marshalWithExtra(body, map[string]any{"type": body.MessageType()})
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]any{"msg": json.RawMessage(msg)}
+	if len(from) > 0 {
+		data["from"] = from
+	}
+	if opt != nil {
+		if len(opt.Users) > 0 {
+			return nil, errors.New("directed users is not supported by chatroom broadcast")
+		}
+		if ext := buildMessageExt(opt); len(ext) > 0 {
+			data["ext"] = ext
+		}
+		if len(opt.Level) > 0 {
+			data["chatroom_msg_level"] = opt.Level
+		}
+	}
+	res = new(BaseRes[ChatroomBroadcastResData])
+	if err = c.doReq(ctx, http.MethodPost, "messages/chatrooms/broadcast", nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ChatType 会话类型
+type ChatType string
+
+const (
+	ChatTypeChat      ChatType = "chat"      // 单聊
+	ChatTypeGroupChat ChatType = "groupchat" // 群聊
+	ChatTypeChatroom  ChatType = "chatroom"  // 聊天室
+)
+
+// RecallMessageOption 撤回消息的可选参数
+type RecallMessageOption struct {
+	Force      bool   // 是否强制撤回, 为 true 时忽略撤回时限及消息是否已过期
+	SyncDevice bool   // 是否将撤回同步给发送方的其他在线设备
+	Ext        string // 撤回消息的扩展信息, 对应 recallMessageExtensionInfo
+}
+
+type RecallMessageResData struct {
+	MsgId    string   `json:"msg_id"`   // 撤回的消息 ID
+	Recalled string   `json:"recalled"` // 撤回结果, 成功时为 yes
+	ChatType ChatType `json:"chattype"` // 会话类型
+	From     string   `json:"from"`     // 消息发送方
+	To       string   `json:"to"`       // 消息接收方
+}
+
+// RecallMessage 撤回消息
+// from 为空时以 admin 身份撤回; to 为单聊的接收方用户名、群组 ID 或聊天室 ID
+func (c *Client) RecallMessage(ctx context.Context, msgId, from, to string, chatType ChatType, opt *RecallMessageOption) (res *BaseRes[RecallMessageResData], err error) {
+	if len(msgId) == 0 {
+		return nil, errors.New("msgId is empty")
+	} else if len(to) == 0 {
+		return nil, errors.New("to is empty")
+	}
+	data := map[string]any{"msg_id": msgId, "to": to, "chat_type": chatType}
+	if len(from) > 0 {
+		data["from"] = from
+	}
+	if opt != nil {
+		if opt.Force {
+			data["force"] = true
+		}
+		if opt.SyncDevice {
+			data["sync_device"] = true
+		}
+		if len(opt.Ext) > 0 {
+			data["recallMessageExtensionInfo"] = opt.Ext
+		}
+	}
+	res = new(BaseRes[RecallMessageResData])
+	if err = c.doReq(ctx, http.MethodPost, "messages/msg_recall", nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DelRoamingMessageResult 单向删除漫游消息结果
+type DelRoamingMessageResult struct {
+	Success []string          `json:"success"` // 删除成功的消息 ID 列表
+	Fail    map[string]string `json:"fail"`    // 删除失败的结果,key为删除失败的消息 ID,value为失败原因
+}
+
+// roamingMessagePath 单向删除漫游消息的路径及会话参数
+func roamingMessagePath(username, targetId string, chatType ChatType) (pathPrefix string, params map[string]any, err error) {
+	switch chatType {
+	case ChatTypeChat:
+		return fmt.Sprintf("rest/message/roaming/chat/user/%s", username), map[string]any{"userId": targetId}, nil
+	case ChatTypeGroupChat:
+		return fmt.Sprintf("rest/message/roaming/group/user/%s", username), map[string]any{"groupId": targetId}, nil
+	}
+	return "", nil, errors.New("chatType only supports chat or groupchat")
+}
+
+// DelRoamingMessage 根据消息 ID 单向删除用户在单聊或群聊会话中的漫游消息
+// 删除后该用户无法再拉取到这些消息, 其他用户不受影响; targetId 为单聊对端用户名或群组 ID
+func (c *Client) DelRoamingMessage(ctx context.Context, username, targetId string, chatType ChatType, msgIds []string) (res *BaseRes[DelRoamingMessageResult], err error) {
+	if len(msgIds) == 0 {
+		return nil, errors.New("minimum count of msgId is 1")
+	} else if len(msgIds) > 50 {
+		return nil, errors.New("maximum count of msgId is 50")
+	}
+	pathSuffix, params, err := roamingMessagePath(username, targetId, chatType)
+	if err != nil {
+		return nil, err
+	}
+	params["msgIdList"] = strings.Join(msgIds, ",")
+	res = new(BaseRes[DelRoamingMessageResult])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DelRoamingMessageByTime 单向删除用户在单聊或群聊会话中 delTime 之前的漫游消息
+// targetId 为单聊对端用户名或群组 ID
+func (c *Client) DelRoamingMessageByTime(ctx context.Context, username, targetId string, chatType ChatType, delTime time.Time) (res *BaseRes[string], err error) {
+	pathSuffix, params, err := roamingMessagePath(username, targetId, chatType)
+	if err != nil {
+		return nil, err
+	}
+	params["delTime"] = delTime.UnixMilli()
+	res = new(BaseRes[string])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix+"/time", params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
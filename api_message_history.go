@@ -0,0 +1,145 @@
+package easemob_server_go
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"time"
+)
+
+// HistoryMessagePayload 历史消息内容
+type HistoryMessagePayload struct {
+	From   string         `json:"from"`
+	To     string         `json:"to"`
+	Bodies []MessageBody  `json:"bodies"` // 消息体, 元素类型为 TextMessageBody、ImageMessageBody 等
+	Ext    map[string]any `json:"ext"`    // 消息扩展字段
+}
+
+func (p *HistoryMessagePayload) UnmarshalJSON(data []byte) (err error) {
+	var payload struct {
+		From   string            `json:"from"`
+		To     string            `json:"to"`
+		Bodies []json.RawMessage `json:"bodies"`
+		Ext    map[string]any    `json:"ext"`
+	}
+	if err = json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	p.From, p.To, p.Ext, p.Bodies = payload.From, payload.To, payload.Ext, make([]MessageBody, 0, len(payload.Bodies))
+	for _, raw := range payload.Bodies {
+		body, err := decodeMessageBody(raw)
+		if err != nil {
+			return err
+		}
+		p.Bodies = append(p.Bodies, body)
+	}
+	return
+}
+
+// HistoryMessage 历史消息记录
+type HistoryMessage struct {
+	MsgId     string                `json:"msg_id"`    // 消息 ID
+	Timestamp int64                 `json:"timestamp"` // 消息发送时间, Unix 时间戳, 单位为毫秒
+	Direction string                `json:"direction"` // 消息方向, 固定为 outgoing
+	From      string                `json:"from"`      // 消息发送方
+	To        string                `json:"to"`        // 消息接收方, 单聊为用户名, 群聊为群组 ID, 聊天室为聊天室 ID
+	ChatType  ChatType              `json:"chat_type"` // 会话类型
+	Payload   HistoryMessagePayload `json:"payload"`   // 消息内容
+}
+
+type HistoryMessageFile struct {
+	Url string `json:"url"` // 历史消息文件的下载地址, 有效期较短, 获取后需尽快下载
+}
+
+// GetHistoryMessageFile 获取历史消息文件的下载地址
+// dateHour 为北京时间的小时, 格式为 YYYYMMDDHH, 如 2024010215
+func (c *Client) GetHistoryMessageFile(ctx context.Context, dateHour string) (res *BaseRes[[]HistoryMessageFile], err error) {
+	if _, err = time.Parse("2006010215", dateHour); err != nil {
+		return nil, fmt.Errorf("dateHour must be formatted as YYYYMMDDHH: %w", err)
+	}
+	pathSuffix := fmt.Sprintf("chatmessages/%s", dateHour)
+	res = new(BaseRes[[]HistoryMessageFile])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ReadHistoryMessages 下载历史消息文件并逐条解析, 文件以流的方式解压, 不会全部读入内存
+// 解析某一行失败时返回该错误并继续解析下一行, 下载或解压失败时返回错误后结束
+// 下载不受 WithTimeout 限制, 需通过 ctx 控制超时
+func (c *Client) ReadHistoryMessages(ctx context.Context, fileUrl string) iter.Seq2[*HistoryMessage, error] {
+	return func(yield func(*HistoryMessage, error) bool) {
+		resp, err := c.transferClient.R().SetContext(ctx).DisableAutoReadResponse().Get(fileUrl)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			yield(nil, fmt.Errorf("download history message file failed, status: %s", resp.Status))
+			return
+		}
+		reader, err := gunzipReader(resp.Body)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			msg := new(HistoryMessage)
+			if err = json.Unmarshal(line, msg); err != nil {
+				msg = nil
+			}
+			if !yield(msg, err) {
+				return
+			}
+		}
+		if err = scanner.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// ExportHistoryMessages 获取指定小时的历史消息文件并逐条解析, dateHour 格式为 YYYYMMDDHH
+func (c *Client) ExportHistoryMessages(ctx context.Context, dateHour string) iter.Seq2[*HistoryMessage, error] {
+	return func(yield func(*HistoryMessage, error) bool) {
+		res, err := c.GetHistoryMessageFile(ctx, dateHour)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, file := range res.Data {
+			for msg, err := range c.ReadHistoryMessages(ctx, file.Url) {
+				if !yield(msg, err) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// gunzipReader 文件内容为 gzip 格式时返回解压后的 reader, 否则原样返回
+// 下载时若服务端设置了 Content-Encoding, 内容可能已被自动解压
+func gunzipReader(reader io.Reader) (io.Reader, error) {
+	bufReader := bufio.NewReader(reader)
+	magic, err := bufReader.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(bufReader)
+	}
+	return bufReader, nil
+}
@@ -0,0 +1,238 @@
+package easemob_server_go
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ImportMessage 导入的消息
+type ImportMessage struct {
+	ChatType     ChatType       `json:"chat_type,omitempty"` // 会话类型, 仅批量导入时使用, 支持 chat 及 groupchat
+	From         string         `json:"from"`                // 消息发送方
+	Target       string         `json:"target"`              // 消息接收方, 单聊为用户名, 群聊为群组 ID
+	Body         MessageBody    `json:"-"`                   // 消息体
+	Ext          map[string]any `json:"ext,omitempty"`       // 消息扩展字段
+	Timestamp    int64          `json:"msg_timestamp"`       // 消息原始发送时间, Unix 时间戳, 单位为毫秒
+	IsAckRead    bool           `json:"is_ack_read"`         // 是否设置为已读
+	NeedDownload bool           `json:"need_download"`       // 是否需要将媒体文件下载后重新上传至环信
+}
+
+func (m *ImportMessage) UnmarshalJSON(data []byte) (err error) {
+	type importMessage ImportMessage
+	var msg struct {
+		*importMessage
+		Type MessageType     `json:"type"`
+		Body json.RawMessage `json:"body"`
+	}
+	msg.importMessage = (*importMessage)(m)
+	if err = json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+	if len(msg.Type) == 0 || len(msg.Body) == 0 {
+		return errors.New("message type or body is empty")
+	}
+	m.Body, err = decodeTypedMessageBody(msg.Type, msg.Body)
+	return
+}
+
+func (m *ImportMessage) data() (data map[string]any, err error) {
+	if m.Body == nil {
+		return nil, errors.New("message body is empty")
+	} else if len(m.From) == 0 || len(m.Target) == 0 {
+		return nil, errors.New("from or target is empty")
+	}
+	data = map[string]any{"from": m.From, "target": m.Target, "type": m.Body.MessageType(), "body": m.Body,
+		"msg_timestamp": m.Timestamp, "is_ack_read": m.IsAckRead, "need_download": m.NeedDownload}
+	if len(m.Ext) > 0 {
+		data["ext"] = m.Ext
+	}
+	return
+}
+
+type ImportMessageResData struct {
+	MsgId string `json:"msg_id"` // 导入后的消息 ID
+}
+
+// ImportUserMessage 导入单聊消息, 导入的消息不会投递给接收方, 仅写入历史消息
+func (c *Client) ImportUserMessage(ctx context.Context, msg *ImportMessage) (res *BaseRes[ImportMessageResData], err error) {
+	data, err := msg.data()
+	if err != nil {
+		return nil, err
+	}
+	res = new(BaseRes[ImportMessageResData])
+	if err = c.doReq(ctx, http.MethodPost, "messages/users/import", nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ImportGroupMessage 导入群聊消息, 导入的消息不会投递给群成员, 仅写入历史消息
+func (c *Client) ImportGroupMessage(ctx context.Context, msg *ImportMessage) (res *BaseRes[ImportMessageResData], err error) {
+	data, err := msg.data()
+	if err != nil {
+		return nil, err
+	}
+	res = new(BaseRes[ImportMessageResData])
+	if err = c.doReq(ctx, http.MethodPost, "messages/chatgroups/import", nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ImportFailure 导入失败的消息
+type ImportFailure struct {
+	Line int   // 消息在文件中的行号, 从1开始
+	Err  error // 失败原因
+}
+
+// ImportResult 批量导入结果
+type ImportResult struct {
+	Succeeded int             // 本次导入成功的数量
+	Skipped   int             // 根据断点记录跳过的数量
+	Failures  []ImportFailure // 本次导入失败的消息
+}
+
+// MessageImporter 从 JSON Lines 文件批量导入消息
+// 文件每行为一条 ImportMessage, 其中 type 及 body 为消息类型及消息体, 如:
+// {"chat_type":"chat","from":"u1","target":"u2","type":"txt","body":{"msg":"hi"},"msg_timestamp":1700000000000}
+// 导入成功的行号会写入断点文件, 重新导入同一文件时跳过这些行, 仅导入之前失败或未导入的消息
+type MessageImporter struct {
+	client         *Client
+	checkpointPath string
+	interval       time.Duration
+	retryCount     int           // 响应为429时的最大重试次数
+	retryBackoff   time.Duration // 响应为429时首次重试的等待时间, 之后每次翻倍
+}
+
+// NewMessageImporter 创建批量导入器
+// checkpointPath 为断点文件路径, 为空时不记录断点; ratePerSecond 为每秒导入的消息数, <= 0 时为默认的 100
+// 导入接口响应为429时等待1秒后重试, 每次重试等待时间翻倍, 最多重试5次
+func (c *Client) NewMessageImporter(checkpointPath string, ratePerSecond int) *MessageImporter {
+	if ratePerSecond <= 0 {
+		ratePerSecond = 100
+	}
+	return &MessageImporter{client: c, checkpointPath: checkpointPath, interval: time.Second / time.Duration(ratePerSecond),
+		retryCount: 5, retryBackoff: time.Second}
+}
+
+// ImportFile 导入指定的 JSON Lines 文件
+func (i *MessageImporter) ImportFile(ctx context.Context, name string) (result *ImportResult, err error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return i.Import(ctx, file)
+}
+
+// Import 从 reader 读取 JSON Lines 格式的消息并导入, 单条消息导入失败不会中断导入
+func (i *MessageImporter) Import(ctx context.Context, reader io.Reader) (result *ImportResult, err error) {
+	done, err := i.loadCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+	var checkpoint *os.File
+	if len(i.checkpointPath) > 0 {
+		if checkpoint, err = os.OpenFile(i.checkpointPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644); err != nil {
+			return nil, err
+		}
+		defer checkpoint.Close()
+	}
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	result = new(ImportResult)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		} else if done[line] {
+			result.Skipped++
+			continue
+		}
+		msg := new(ImportMessage)
+		if err = json.Unmarshal(content, msg); err != nil {
+			result.Failures = append(result.Failures, ImportFailure{Line: line, Err: err})
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-ticker.C:
+		}
+		if err = i.importMessage(ctx, msg); err != nil {
+			result.Failures = append(result.Failures, ImportFailure{Line: line, Err: err})
+			continue
+		}
+		result.Succeeded++
+		if checkpoint != nil {
+			if _, err = fmt.Fprintln(checkpoint, line); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, scanner.Err()
+}
+
+// importMessage 导入单条消息, 响应为429时退避后重试
+func (i *MessageImporter) importMessage(ctx context.Context, msg *ImportMessage) (err error) {
+	backoff := i.retryBackoff
+	for retry := 0; ; retry++ {
+		var apiErr ApiError
+		if err = i.sendMessage(ctx, msg); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || retry >= i.retryCount {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (i *MessageImporter) sendMessage(ctx context.Context, msg *ImportMessage) (err error) {
+	switch msg.ChatType {
+	case ChatTypeChat, "":
+		_, err = i.client.ImportUserMessage(ctx, msg)
+	case ChatTypeGroupChat:
+		_, err = i.client.ImportGroupMessage(ctx, msg)
+	default:
+		err = fmt.Errorf("unsupported chat_type: %s", msg.ChatType)
+	}
+	return
+}
+
+// loadCheckpoint 读取断点文件中导入成功的行号
+func (i *MessageImporter) loadCheckpoint() (done map[int]bool, err error) {
+	done = make(map[int]bool)
+	if len(i.checkpointPath) == 0 {
+		return
+	}
+	file, err := os.Open(i.checkpointPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// 写入中断时最后一行可能不完整, 忽略无法解析的行
+		if line, err := strconv.Atoi(string(bytes.TrimSpace(scanner.Bytes()))); err == nil {
+			done[line] = true
+		}
+	}
+	return done, scanner.Err()
+}
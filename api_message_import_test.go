@@ -0,0 +1,101 @@
+package easemob_server_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// importServer 模拟消息导入接口, 记录每个发送方的请求次数
+type importServer struct {
+	mu       sync.Mutex
+	calls    map[string]int
+	throttle map[string]int // 发送方前 n 次请求返回429
+	reject   map[string]bool
+}
+
+func (s *importServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var msg struct {
+		From string `json:"from"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&msg)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[msg.From]++
+	if s.calls[msg.From] <= s.throttle[msg.From] {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":"resource_exhausted","error_description":"too many requests"}`))
+		return
+	} else if s.reject[msg.From] {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"illegal_argument","error_description":"rejected"}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"data":{"msg_id":"1"}}`))
+}
+
+func TestMessageImporterResumeFromCheckpoint(t *testing.T) {
+	s := &importServer{calls: make(map[string]int), throttle: map[string]int{"u2": 2}, reject: map[string]bool{"u3": true}}
+	c := newTestClient(t, s)
+	lines := strings.Join([]string{
+		`{"from":"u1","target":"t","type":"txt","body":{"msg":"1"}}`,
+		`{"from":"u2","target":"t","type":"txt","body":{"msg":"2"}}`,
+		`{"from":"u3","target":"t","type":"txt","body":{"msg":"3"}}`,
+		``,
+		`{"from":"u4","target":"t","type":"txt","body":{"msg":"5"}}`,
+	}, "\n")
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	importer := c.NewMessageImporter(checkpoint, 1000)
+	importer.retryBackoff = time.Millisecond
+
+	result, err := importer.Import(context.Background(), strings.NewReader(lines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Succeeded != 3 || len(result.Failures) != 1 || result.Failures[0].Line != 3 {
+		t.Fatalf("unexpected first result %+v", result)
+	}
+	if n := s.calls["u2"]; n != 3 {
+		t.Fatalf("expected throttled message retried until success, got %d calls", n)
+	}
+
+	s.mu.Lock()
+	s.reject["u3"] = false
+	s.mu.Unlock()
+	if result, err = importer.Import(context.Background(), strings.NewReader(lines)); err != nil {
+		t.Fatal(err)
+	}
+	if result.Skipped != 3 || result.Succeeded != 1 || len(result.Failures) != 0 {
+		t.Fatalf("unexpected resumed result %+v", result)
+	}
+	for from, want := range map[string]int{"u1": 1, "u2": 3, "u3": 2, "u4": 1} {
+		if n := s.calls[from]; n != want {
+			t.Fatalf("expected %d calls from %s, got %d", want, from, n)
+		}
+	}
+}
+
+func TestMessageImporterRetryLimit(t *testing.T) {
+	s := &importServer{calls: make(map[string]int), throttle: map[string]int{"u1": 10}}
+	c := newTestClient(t, s)
+	importer := c.NewMessageImporter("", 1000)
+	importer.retryBackoff = time.Millisecond
+
+	result, err := importer.Import(context.Background(), strings.NewReader(`{"from":"u1","target":"t","type":"txt","body":{"msg":"1"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var apiErr ApiError
+	if len(result.Failures) != 1 || !errors.As(result.Failures[0].Err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 failure after retries, got %+v", result)
+	}
+	if n := s.calls["u1"]; n != importer.retryCount+1 {
+		t.Fatalf("expected %d calls, got %d", importer.retryCount+1, n)
+	}
+}
@@ -0,0 +1,106 @@
+package easemob_server_go
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// captureHandler 返回记录请求体的 http.Handler, 对所有请求返回 response
+func captureHandler(response string) (http.Handler, *map[string]any) {
+	captured := new(map[string]any)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*captured = nil
+		_ = json.Unmarshal(body, captured)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}), captured
+}
+
+func TestSendChatroomBroadcastBody(t *testing.T) {
+	handler, captured := captureHandler(`{"data":{"id":"1"}}`)
+	c := newTestClient(t, handler)
+	opt := &MessageOption{Ext: map[string]any{"k": "v"}, Level: ChatroomMsgLevelHigh}
+	if _, err := c.SendChatroomBroadcast(context.Background(), "admin", TextMessageBody{Msg: "hi"}, opt); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]any{
+		"msg":                map[string]any{"type": "txt", "msg": "hi"},
+		"from":               "admin",
+		"ext":                map[string]any{"k": "v"},
+		"chatroom_msg_level": "high",
+	}
+	if !reflect.DeepEqual(*captured, expected) {
+		t.Fatalf("unexpected broadcast body: %v", *captured)
+	}
+	if _, err := c.SendChatroomBroadcast(context.Background(), "", TextMessageBody{Msg: "hi"}, &MessageOption{Users: []string{"u1"}}); err == nil {
+		t.Fatal("expected error for directed users in broadcast")
+	}
+}
+
+func TestMessageOptionScope(t *testing.T) {
+	handler, captured := captureHandler(`{"data":{}}`)
+	c := newTestClient(t, handler)
+	ctx, body := context.Background(), TextMessageBody{Msg: "hi"}
+	if _, err := c.SendUserMessage(ctx, "", []string{"u1"}, body, &MessageOption{Users: []string{"u2"}}); err == nil {
+		t.Fatal("expected error for directed users in user message")
+	}
+	if _, err := c.SendGroupMessage(ctx, "", []string{"g1"}, body, &MessageOption{Level: ChatroomMsgLevelLow}); err == nil {
+		t.Fatal("expected error for message level in group message")
+	}
+	if _, err := c.SendUserMessage(ctx, "", []string{"u1"}, body, &MessageOption{SyncDevice: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"users", "chatroom_msg_level"} {
+		if _, exist := (*captured)[key]; exist {
+			t.Fatalf("user message must not carry %s: %v", key, *captured)
+		}
+	}
+	opt := &MessageOption{Users: []string{"u2"}, Level: ChatroomMsgLevelLow}
+	if _, err := c.SendChatroomMessage(ctx, "", []string{"r1"}, body, opt); err != nil {
+		t.Fatal(err)
+	}
+	if (*captured)["chatroom_msg_level"] != "low" || !reflect.DeepEqual((*captured)["users"], []any{"u2"}) {
+		t.Fatalf("unexpected chatroom message body: %v", *captured)
+	}
+}
+
+func TestLocationMessageBodyCoordinates(t *testing.T) {
+	for _, raw := range []string{
+		`{"type":"loc","lat":39.9042,"lng":116.4074,"addr":"北京"}`,
+		`{"type":"loc","lat":"39.9042","lng":"116.4074","addr":"北京"}`,
+	} {
+		body, err := decodeMessageBody(json.RawMessage(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := LocationMessageBody{Lat: "39.9042", Lng: "116.4074", Addr: "北京"}
+		if body != want {
+			t.Fatalf("decode %s: got %+v", raw, body)
+		}
+	}
+	data, err := json.Marshal(LocationMessageBody{Lat: "39.9042", Lng: "116.4074"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"lat":"39.9042","lng":"116.4074","addr":""}` {
+		t.Fatalf("unexpected send body %s", data)
+	}
+}
+
+func TestUnknownMessageBodyEmptyRaw(t *testing.T) {
+	data, err := json.Marshal(UnknownMessageBody{Type: "future"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{}` {
+		t.Fatalf("unexpected body %s", data)
+	}
+	if _, err = marshalWithExtra(UnknownMessageBody{Type: "future"}, map[string]any{"type": "future"}); err != nil {
+		t.Fatal(err)
+	}
+}
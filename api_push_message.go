@@ -0,0 +1,220 @@
+package easemob_server_go
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// PushMessage 推送消息内容, 各厂商字段在发送时会序列化为推送接口要求的格式
+// 通过 PushMsgMap 方法转换后用于 SyncPushNotification 等推送接口
+type PushMessage struct {
+	Title    string            `json:"title,omitempty"`    // 推送标题, 未使用模板时必填
+	SubTitle string            `json:"subTitle,omitempty"` // 推送子标题
+	Content  string            `json:"content,omitempty"`  // 推送内容, 未使用模板时必填
+	Ext      map[string]any    `json:"ext,omitempty"`      // 透传给客户端的自定义扩展
+	Config   *PushConfig       `json:"config,omitempty"`   // 通用配置
+	Template *PushTemplateArgs `json:"template,omitempty"` // 使用推送模板生成推送的标题及内容
+
+	APNs   *APNsPush   `json:"apns,omitempty"`   // 苹果推送配置
+	FCM    *FCMPush    `json:"fcm,omitempty"`    // 谷歌推送配置
+	Huawei *HuaweiPush `json:"huawei,omitempty"` // 华为推送配置
+	Honor  *HonorPush  `json:"honor,omitempty"`  // 荣耀推送配置
+	Xiaomi *XiaomiPush `json:"xiaomi,omitempty"` // 小米推送配置
+	OPPO   *OPPOPush   `json:"oppo,omitempty"`   // OPPO推送配置
+	Vivo   *VivoPush   `json:"vivo,omitempty"`   // vivo推送配置
+	Meizu  *MeizuPush  `json:"meizu,omitempty"`  // 魅族推送配置
+}
+
+// PushConfig 推送通用配置
+type PushConfig struct {
+	ClickAction *PushClickAction `json:"clickAction,omitempty"` // 点击通知后的动作
+	Badge       *PushBadge       `json:"badge,omitempty"`       // 应用角标
+}
+
+// PushClickAction 点击通知后的动作, 三个字段同时设置时优先级为 Url > Action > Activity
+type PushClickAction struct {
+	Url      string `json:"url,omitempty"`      // 打开网页
+	Action   string `json:"action,omitempty"`   // 打开应用内的自定义页面(Android intent action)
+	Activity string `json:"activity,omitempty"` // 打开应用内的指定页面(Android Activity 类名)
+}
+
+// PushBadge 应用角标, AddNum 与 SetNum 同时设置时以 SetNum 为准
+type PushBadge struct {
+	AddNum   *int   `json:"addNum,omitempty"`   // 角标增加的数量
+	SetNum   *int   `json:"setNum,omitempty"`   // 角标设置的数量
+	Activity string `json:"activity,omitempty"` // 应用入口 Activity 类名, 华为、荣耀必填
+}
+
+// APNsPush 苹果推送配置
+type APNsPush struct {
+	Badge             *int           `json:"badge,omitempty"`             // 角标数
+	Sound             string         `json:"sound,omitempty"`             // 提示音文件名
+	Category          string         `json:"category,omitempty"`          // 通知类别
+	ThreadId          string         `json:"threadId,omitempty"`          // 通知分组 ID
+	InterruptionLevel string         `json:"interruptionLevel,omitempty"` // 通知中断级别, passive、active、time-sensitive 或 critical
+	MutableContent    bool           `json:"mutableContent,omitempty"`    // 是否允许 Notification Service Extension 修改通知
+	ContentAvailable  bool           `json:"contentAvailable,omitempty"`  // 是否为静默推送
+	Extra             map[string]any `json:"-"`                           // 其他 APNs payload 字段
+}
+
+func (p APNsPush) MarshalJSON() ([]byte, error) {
+	type vendor APNsPush
+	return marshalWithExtra(vendor(p), p.Extra)
+}
+
+// FCMPush 谷歌推送配置
+type FCMPush struct {
+	ChannelId   string         `json:"channelId,omitempty"`   // 通知渠道 ID
+	Priority    string         `json:"priority,omitempty"`    // 消息优先级, normal 或 high
+	Sound       string         `json:"sound,omitempty"`       // 提示音文件名
+	Tag         string         `json:"tag,omitempty"`         // 通知标签, 相同标签的通知会被替换
+	ClickAction string         `json:"clickAction,omitempty"` // 点击通知后打开的 intent filter
+	Extra       map[string]any `json:"-"`                     // 其他 FCM 字段
+}
+
+func (p FCMPush) MarshalJSON() ([]byte, error) {
+	type vendor FCMPush
+	return marshalWithExtra(vendor(p), p.Extra)
+}
+
+// HuaweiPush 华为推送配置
+type HuaweiPush struct {
+	ChannelId  string         `json:"channelId,omitempty"`  // 通知渠道 ID
+	Importance string         `json:"importance,omitempty"` // 消息分类级别, LOW 或 NORMAL
+	Category   string         `json:"category,omitempty"`   // 自分类消息类型, 如 IM、VOIP
+	Sound      string         `json:"sound,omitempty"`      // 提示音文件名
+	Extra      map[string]any `json:"-"`                    // 其他华为推送字段
+}
+
+func (p HuaweiPush) MarshalJSON() ([]byte, error) {
+	type vendor HuaweiPush
+	return marshalWithExtra(vendor(p), p.Extra)
+}
+
+// HonorPush 荣耀推送配置
+type HonorPush struct {
+	Importance string         `json:"importance,omitempty"` // 消息分类级别, LOW 或 NORMAL
+	Extra      map[string]any `json:"-"`                    // 其他荣耀推送字段
+}
+
+func (p HonorPush) MarshalJSON() ([]byte, error) {
+	type vendor HonorPush
+	return marshalWithExtra(vendor(p), p.Extra)
+}
+
+// XiaomiPush 小米推送配置
+type XiaomiPush struct {
+	ChannelId string         `json:"channelId,omitempty"` // 通知渠道 ID
+	Extra     map[string]any `json:"-"`                   // 其他小米推送字段
+}
+
+func (p XiaomiPush) MarshalJSON() ([]byte, error) {
+	type vendor XiaomiPush
+	return marshalWithExtra(vendor(p), p.Extra)
+}
+
+// OPPOPush OPPO推送配置
+type OPPOPush struct {
+	ChannelId   string         `json:"channelId,omitempty"`   // 通知渠道 ID
+	Category    string         `json:"category,omitempty"`    // 消息分类, 如 IM、ACCOUNT
+	NotifyLevel int            `json:"notifyLevel,omitempty"` // 通知栏消息提醒等级, 1 通知栏, 2 通知栏+锁屏, 16 通知栏+锁屏+横幅+震动+铃声
+	Extra       map[string]any `json:"-"`                     // 其他 OPPO 推送字段
+}
+
+func (p OPPOPush) MarshalJSON() ([]byte, error) {
+	type vendor OPPOPush
+	return marshalWithExtra(vendor(p), p.Extra)
+}
+
+// VivoPush vivo推送配置
+type VivoPush struct {
+	Category       string         `json:"category,omitempty"`       // 消息二级分类, 如 IM、ACCOUNT
+	Classification *int           `json:"classification,omitempty"` // 消息类型, 0 运营消息, 1 系统消息
+	Extra          map[string]any `json:"-"`                        // 其他 vivo 推送字段
+}
+
+func (p VivoPush) MarshalJSON() ([]byte, error) {
+	type vendor VivoPush
+	return marshalWithExtra(vendor(p), p.Extra)
+}
+
+// MeizuPush 魅族推送配置
+type MeizuPush struct {
+	NoticeMsgType   *int           `json:"noticeMsgType,omitempty"`   // 消息分类, 0 公信消息, 1 私信消息
+	NoticeChannelId string         `json:"noticeChannelId,omitempty"` // 通知渠道 ID
+	Extra           map[string]any `json:"-"`                         // 其他魅族推送字段
+}
+
+func (p MeizuPush) MarshalJSON() ([]byte, error) {
+	type vendor MeizuPush
+	return marshalWithExtra(vendor(p), p.Extra)
+}
+
+// marshalWithExtra 序列化 v 并合并 extra 中的字段, 与 v 中已有的字段同名时以 v 为准
+func marshalWithExtra(v any, extra map[string]any) ([]byte, error) {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return jsonBytes, err
+	}
+	fields := make(map[string]any)
+	if err = json.Unmarshal(jsonBytes, &fields); err != nil {
+		return nil, err
+	}
+	for key, val := range extra {
+		if _, exist := fields[key]; !exist {
+			fields[key] = val
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// Validate 校验推送消息的必填字段及取值范围
+func (m PushMessage) Validate() error {
+	if m.Template != nil {
+		if len(m.Template.Name) == 0 {
+			return errors.New("push template name is empty")
+		}
+	} else if len(m.Title) == 0 || len(m.Content) == 0 {
+		return errors.New("push title or content is empty")
+	}
+	if m.Config != nil && m.Config.Badge != nil {
+		if badge := m.Config.Badge; (badge.AddNum != nil && *badge.AddNum < 0) || (badge.SetNum != nil && *badge.SetNum < 0) {
+			return errors.New("push badge must not be negative")
+		}
+	}
+	if m.APNs != nil && m.APNs.Badge != nil && *m.APNs.Badge < 0 {
+		return errors.New("apns badge must not be negative")
+	}
+	if m.FCM != nil && m.FCM.Priority != "" && m.FCM.Priority != "normal" && m.FCM.Priority != "high" {
+		return fmt.Errorf("fcm priority must be normal or high, got %q", m.FCM.Priority)
+	}
+	if m.Huawei != nil && m.Huawei.Importance != "" && m.Huawei.Importance != "LOW" && m.Huawei.Importance != "NORMAL" {
+		return fmt.Errorf("huawei importance must be LOW or NORMAL, got %q", m.Huawei.Importance)
+	}
+	if m.Honor != nil && m.Honor.Importance != "" && m.Honor.Importance != "LOW" && m.Honor.Importance != "NORMAL" {
+		return fmt.Errorf("honor importance must be LOW or NORMAL, got %q", m.Honor.Importance)
+	}
+	if m.Vivo != nil && m.Vivo.Classification != nil && *m.Vivo.Classification != 0 && *m.Vivo.Classification != 1 {
+		return fmt.Errorf("vivo classification must be 0 or 1, got %d", *m.Vivo.Classification)
+	}
+	if m.Meizu != nil && m.Meizu.NoticeMsgType != nil && *m.Meizu.NoticeMsgType != 0 && *m.Meizu.NoticeMsgType != 1 {
+		return fmt.Errorf("meizu notice msg type must be 0 or 1, got %d", *m.Meizu.NoticeMsgType)
+	}
+	return nil
+}
+
+// PushMsgMap 校验推送消息并转换为推送接口使用的 PushMsgMap
+func (m PushMessage) PushMsgMap() (pushMsg PushMsgMap, err error) {
+	if err = m.Validate(); err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(jsonBytes, &pushMsg); err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,45 @@
+package easemob_server_go
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPushMessagePushMsgMap(t *testing.T) {
+	badge := 1
+	pushMsg, err := PushMessage{Title: "title", Content: "content",
+		APNs: &APNsPush{Badge: &badge, Extra: map[string]any{"custom": "v"}}}.PushMsgMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler, captured := captureHandler(`{"data":[]}`)
+	c := newTestClient(t, handler)
+	if _, err = c.SyncPushNotification(context.Background(), "u1", pushMsg, PushStrategyThirdPartyFirst); err != nil {
+		t.Fatal(err)
+	}
+	sent := (*captured)["pushMessage"].(map[string]any)
+	apns := sent["apns"].(map[string]any)
+	if sent["title"] != "title" || sent["content"] != "content" || apns["badge"] != float64(1) || apns["custom"] != "v" {
+		t.Fatalf("unexpected push message %v", sent)
+	}
+
+	if _, err = (PushMessage{Title: "title"}).PushMsgMap(); err == nil {
+		t.Fatal("expected validation error for empty content")
+	}
+}
+
+func TestMeizuPushFields(t *testing.T) {
+	private, invalid := 1, 2
+	pushMsg, err := PushMessage{Title: "title", Content: "content",
+		Meizu: &MeizuPush{NoticeMsgType: &private, NoticeChannelId: "im", Extra: map[string]any{"custom": "v"}}}.PushMsgMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	meizu := pushMsg["meizu"].(map[string]any)
+	if meizu["noticeMsgType"] != float64(1) || meizu["noticeChannelId"] != "im" || meizu["custom"] != "v" {
+		t.Fatalf("unexpected meizu config %v", meizu)
+	}
+	if _, err = (PushMessage{Title: "title", Content: "content", Meizu: &MeizuPush{NoticeMsgType: &invalid}}).PushMsgMap(); err == nil {
+		t.Fatal("expected validation error for invalid meizu notice msg type")
+	}
+}
@@ -22,6 +22,12 @@ func (bm PushMsgMap) SetBodyMap(key string, value func(b PushMsgMap)) PushMsgMap
 	return bm
 }
 
+// SetTemplate 使用推送模板生成推送的标题及内容
+func (bm PushMsgMap) SetTemplate(args PushTemplateArgs) PushMsgMap {
+	bm["template"] = args
+	return bm
+}
+
 // PushStrategy 推送策略
 type PushStrategy int
 
@@ -0,0 +1,176 @@
+package easemob_server_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PushDisplayStyle 离线推送通知的展示方式
+type PushDisplayStyle int
+
+const (
+	PushDisplayStyleSummary PushDisplayStyle = 0 // 仅显示"您有一条新消息"
+	PushDisplayStyleDetail  PushDisplayStyle = 1 // 显示消息发送方昵称及消息内容
+)
+
+// SetPushNickname 设置离线推送时显示的昵称
+func (c *Client) SetPushNickname(ctx context.Context, username, nickname string) (res *UserBaseRes[[]UserEntity], err error) {
+	data := map[string]any{"nickname": nickname}
+	pathSuffix := fmt.Sprintf("users/%s", username)
+	res = new(UserBaseRes[[]UserEntity])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// SetPushDisplayStyle 设置离线推送通知的展示方式
+func (c *Client) SetPushDisplayStyle(ctx context.Context, username string, style PushDisplayStyle) (res *UserBaseRes[[]UserEntity], err error) {
+	data := map[string]any{"notification_display_style": style}
+	pathSuffix := fmt.Sprintf("users/%s", username)
+	res = new(UserBaseRes[[]UserEntity])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// PushMode 离线推送方式
+type PushMode string
+
+const (
+	PushModeDefault PushMode = "DEFAULT" // 会话级别使用 App 级别的设置
+	PushModeAll     PushMode = "ALL"     // 接收所有消息的推送
+	PushModeAt      PushMode = "AT"      // 仅接收提及(@)自己的消息的推送
+	PushModeNone    PushMode = "NONE"    // 不接收推送
+)
+
+// PushSetting 离线推送设置, 为 nil 的字段不修改
+// 取消免打扰时将 IgnoreInterval 设置为空字符串或将 IgnoreDuration 设置为0
+type PushSetting struct {
+	Type           PushMode `json:"type,omitempty"`           // 推送方式
+	IgnoreInterval *string  `json:"ignoreInterval,omitempty"` // 每天的免打扰时间段, 格式为 HH:MM-HH:MM, 如 21:30-08:00
+	IgnoreDuration *int64   `json:"ignoreDuration,omitempty"` // 从设置时起的免打扰时长, 单位为毫秒, 最大为7天
+}
+
+// pushSettingPath 离线推送设置的路径, chatType 为空时为 App 级别的设置
+func pushSettingPath(username string, chatType ChatType, targetId string) (pathSuffix string, err error) {
+	switch chatType {
+	case "":
+		return fmt.Sprintf("users/%s/notification/user/%s", username, username), nil
+	case ChatTypeChat, ChatTypeGroupChat:
+		return fmt.Sprintf("users/%s/notification/%s/%s", username, chatType, targetId), nil
+	}
+	return "", errors.New("chatType only supports chat or groupchat")
+}
+
+// SetUserPushSetting 设置用户在 App 级别的推送方式及免打扰时段
+func (c *Client) SetUserPushSetting(ctx context.Context, username string, setting PushSetting) (res *BaseRes[PushSetting], err error) {
+	return c.SetConversationPushSetting(ctx, username, "", "", setting)
+}
+
+// GetUserPushSetting 获取用户在 App 级别的推送方式及免打扰时段
+func (c *Client) GetUserPushSetting(ctx context.Context, username string) (res *BaseRes[PushSetting], err error) {
+	return c.GetConversationPushSetting(ctx, username, "", "")
+}
+
+// SetConversationPushSetting 设置用户在单聊或群聊会话中的推送方式及免打扰时段
+// targetId 为单聊对端用户名或群组 ID
+func (c *Client) SetConversationPushSetting(ctx context.Context, username string, chatType ChatType, targetId string, setting PushSetting) (res *BaseRes[PushSetting], err error) {
+	pathSuffix, err := pushSettingPath(username, chatType, targetId)
+	if err != nil {
+		return nil, err
+	}
+	res = new(BaseRes[PushSetting])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, setting, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetConversationPushSetting 获取用户在单聊或群聊会话中的推送方式及免打扰时段
+// targetId 为单聊对端用户名或群组 ID
+func (c *Client) GetConversationPushSetting(ctx context.Context, username string, chatType ChatType, targetId string) (res *BaseRes[PushSetting], err error) {
+	pathSuffix, err := pushSettingPath(username, chatType, targetId)
+	if err != nil {
+		return nil, err
+	}
+	res = new(BaseRes[PushSetting])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type PushLanguage struct {
+	Language string `json:"language"` // 推送翻译的目标语言代码, 如 EU、zh
+}
+
+// SetPushLanguage 设置推送翻译的目标语言
+func (c *Client) SetPushLanguage(ctx context.Context, username, language string) (res *BaseRes[PushLanguage], err error) {
+	data := map[string]any{"translationLanguage": language}
+	pathSuffix := fmt.Sprintf("users/%s/notification/language", username)
+	res = new(BaseRes[PushLanguage])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetPushLanguage 获取推送翻译的目标语言
+func (c *Client) GetPushLanguage(ctx context.Context, username string) (res *BaseRes[PushLanguage], err error) {
+	pathSuffix := fmt.Sprintf("users/%s/notification/language", username)
+	res = new(BaseRes[PushLanguage])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// PushDevice 用户绑定的推送设备
+type PushDevice struct {
+	DeviceId     string `json:"deviceId"`     // 设备 ID
+	DeviceToken  string `json:"deviceToken"`  // 推送 token
+	NotifierName string `json:"notifierName"` // 推送证书名称, 即在控制台配置的证书名称
+}
+
+// BindPushDevice 为用户绑定设备推送 token
+// 返回结果 Data 为用户当前绑定的所有推送设备
+func (c *Client) BindPushDevice(ctx context.Context, username string, device PushDevice) (res *BaseRes[[]PushDevice], err error) {
+	if len(device.DeviceId) == 0 || len(device.DeviceToken) == 0 || len(device.NotifierName) == 0 {
+		return nil, errors.New("deviceId, deviceToken or notifierName is empty")
+	}
+	pathSuffix := fmt.Sprintf("users/%s/push/binding", username)
+	res = new(BaseRes[[]PushDevice])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, device, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// UnbindPushDevice 解除用户设备的推送 token 绑定
+// 返回结果 Data 为用户当前绑定的所有推送设备
+func (c *Client) UnbindPushDevice(ctx context.Context, username, deviceId, notifierName string) (res *BaseRes[[]PushDevice], err error) {
+	if len(deviceId) == 0 || len(notifierName) == 0 {
+		return nil, errors.New("deviceId or notifierName is empty")
+	}
+	data := PushDevice{DeviceId: deviceId, NotifierName: notifierName}
+	pathSuffix := fmt.Sprintf("users/%s/push/binding", username)
+	res = new(BaseRes[[]PushDevice])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetPushDevices 获取用户绑定的推送设备
+func (c *Client) GetPushDevices(ctx context.Context, username string) (res *BaseRes[[]PushDevice], err error) {
+	pathSuffix := fmt.Sprintf("users/%s/push/binding", username)
+	res = new(BaseRes[[]PushDevice])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,20 @@
+package easemob_server_go
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPushSettingClearDoNotDisturb(t *testing.T) {
+	interval, duration := "", int64(0)
+	data, err := json.Marshal(PushSetting{Type: PushModeAll, IgnoreInterval: &interval, IgnoreDuration: &duration})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"type":"ALL","ignoreInterval":"","ignoreDuration":0}` {
+		t.Fatalf("unexpected body %s", data)
+	}
+	if data, _ = json.Marshal(PushSetting{Type: PushModeAll}); string(data) != `{"type":"ALL"}` {
+		t.Fatalf("unexpected body %s", data)
+	}
+}
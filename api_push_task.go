@@ -0,0 +1,115 @@
+package easemob_server_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PushTaskStatus 推送任务状态
+type PushTaskStatus string
+
+const (
+	PushTaskStatusCreated  PushTaskStatus = "CREATED"  // 已创建, 等待执行
+	PushTaskStatusSending  PushTaskStatus = "SENDING"  // 推送中
+	PushTaskStatusFinished PushTaskStatus = "FINISHED" // 推送完成
+	PushTaskStatusCanceled PushTaskStatus = "CANCELED" // 已取消
+	PushTaskStatusFailed   PushTaskStatus = "FAILED"   // 推送失败
+)
+
+// Terminal 任务是否已结束, 结束后状态不会再变化
+func (s PushTaskStatus) Terminal() bool {
+	return s == PushTaskStatusFinished || s == PushTaskStatusCanceled || s == PushTaskStatusFailed
+}
+
+// PushTask 推送任务
+type PushTask struct {
+	TaskId     int64          `json:"taskId"`     // 推送任务 ID
+	Status     PushTaskStatus `json:"status"`     // 任务状态
+	StartDate  string         `json:"startDate"`  // 定时推送的开始时间, 格式为 2006-01-02 15:04:05
+	CreateTime int64          `json:"createTime"` // 创建时间, Unix 时间戳, 单位为毫秒
+	FinishTime int64          `json:"finishTime"` // 完成时间, Unix 时间戳, 单位为毫秒, 未完成时为0
+}
+
+// GetPushTask 查询标签推送或全量推送任务的状态
+func (c *Client) GetPushTask(ctx context.Context, taskId int64) (res *BaseRes[PushTask], err error) {
+	pathSuffix := fmt.Sprintf("push/task/%d", taskId)
+	res = new(BaseRes[PushTask])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// CancelPushTask 取消尚未开始执行的定时推送任务
+func (c *Client) CancelPushTask(ctx context.Context, taskId int64) (res *BaseRes[PushTask], err error) {
+	pathSuffix := fmt.Sprintf("push/task/%d", taskId)
+	res = new(BaseRes[PushTask])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// WaitPushTask 按 interval 轮询推送任务直到任务结束, interval <= 0 时为5秒
+// ctx 结束时返回 ctx.Err() 及最后一次查询到的任务状态
+func (c *Client) WaitPushTask(ctx context.Context, taskId int64, interval time.Duration) (task *PushTask, err error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var res *BaseRes[PushTask]
+	for {
+		if res, err = c.GetPushTask(ctx, taskId); err != nil {
+			return task, err
+		}
+		task = &res.Data
+		if task.Status.Terminal() {
+			return task, nil
+		}
+		select {
+		case <-ctx.Done():
+			return task, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// PushStatistics 推送统计数据
+type PushStatistics struct {
+	Date    string `json:"date,omitempty"` // 统计日期, 格式为 2006-01-02, 仅按天统计时返回
+	Send    int64  `json:"send"`           // 推送数
+	Success int64  `json:"success"`        // 推送成功数
+	Arrive  int64  `json:"arrive"`         // 送达数
+	Click   int64  `json:"click"`          // 点击数
+}
+
+// GetPushTaskStatistics 查询推送任务的送达及点击统计
+func (c *Client) GetPushTaskStatistics(ctx context.Context, taskId int64) (res *BaseRes[PushStatistics], err error) {
+	pathSuffix := fmt.Sprintf("push/data/%d", taskId)
+	res = new(BaseRes[PushStatistics])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetPushDailyStatistics 按天查询 App 的推送送达及点击统计, 包含 startDate 及 endDate 当天, 最多31天
+func (c *Client) GetPushDailyStatistics(ctx context.Context, startDate, endDate time.Time) (res *BaseRes[[]PushStatistics], err error) {
+	startDay := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	endDay := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
+	if endDay.Before(startDay) {
+		return nil, errors.New("endDate is before startDate")
+	} else if days := int(endDay.Sub(startDay)/(24*time.Hour)) + 1; days > 31 {
+		return nil, fmt.Errorf("date range is too long, got %d days, maximum is 31 days", days)
+	}
+	params := map[string]any{"startDate": startDate.Format("2006-01-02"), "endDate": endDate.Format("2006-01-02")}
+	res = new(BaseRes[[]PushStatistics])
+	if err = c.doReq(ctx, http.MethodGet, "push/data", params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
@@ -0,0 +1,24 @@
+package easemob_server_go
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGetPushDailyStatisticsRange(t *testing.T) {
+	handler, _ := captureHandler(`{"data":[]}`)
+	c := newTestClient(t, handler)
+	day := func(month time.Month, d, hour int) time.Time {
+		return time.Date(2024, month, d, hour, 0, 0, 0, time.Local)
+	}
+	if _, err := c.GetPushDailyStatistics(context.Background(), day(time.January, 1, 0), day(time.January, 31, 23)); err != nil {
+		t.Fatalf("expected 31 days allowed, got %v", err)
+	}
+	if _, err := c.GetPushDailyStatistics(context.Background(), day(time.January, 1, 12), day(time.February, 1, 0)); err == nil {
+		t.Fatal("expected 32 calendar days rejected")
+	}
+	if _, err := c.GetPushDailyStatistics(context.Background(), day(time.January, 2, 0), day(time.January, 1, 0)); err == nil {
+		t.Fatal("expected endDate before startDate rejected")
+	}
+}
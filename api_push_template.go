@@ -0,0 +1,132 @@
+package easemob_server_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PushTemplate 推送模板, 标题及内容中的 {0}、{1} 等占位符在推送时由参数依次替换
+type PushTemplate struct {
+	Name           string `json:"name"`            // 模板名称
+	TitlePattern   string `json:"title_pattern"`   // 推送标题模板, 如 "{0}"
+	ContentPattern string `json:"content_pattern"` // 推送内容模板, 如 "{0}: {1}"
+	CreatedAt      int64  `json:"createdAt,omitempty"`
+	UpdatedAt      int64  `json:"updatedAt,omitempty"`
+}
+
+// CreatePushTemplate 创建推送模板, 同名模板已存在时会被覆盖
+// 多语言推送可为每种语言创建一个模板, 发送时根据接收方语言选择模板名称
+func (c *Client) CreatePushTemplate(ctx context.Context, name, titlePattern, contentPattern string) (res *BaseRes[PushTemplate], err error) {
+	if len(name) == 0 {
+		return nil, errors.New("name is empty")
+	}
+	data := map[string]any{"name": name, "title_pattern": titlePattern, "content_pattern": contentPattern}
+	res = new(BaseRes[PushTemplate])
+	if err = c.doReq(ctx, http.MethodPost, "notification/template", nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetPushTemplate 查询推送模板
+func (c *Client) GetPushTemplate(ctx context.Context, name string) (res *BaseRes[PushTemplate], err error) {
+	pathSuffix := fmt.Sprintf("notification/template/%s", name)
+	res = new(BaseRes[PushTemplate])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DeletePushTemplate 删除推送模板
+func (c *Client) DeletePushTemplate(ctx context.Context, name string) (res *BaseRes[PushTemplate], err error) {
+	pathSuffix := fmt.Sprintf("notification/template/%s", name)
+	res = new(BaseRes[PushTemplate])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// PushTemplateArgs 使用推送模板时的参数
+type PushTemplateArgs struct {
+	Name        string   `json:"name"`                   // 模板名称
+	TitleArgs   []string `json:"title_args,omitempty"`   // 依次替换标题模板中的占位符
+	ContentArgs []string `json:"content_args,omitempty"` // 依次替换内容模板中的占位符
+}
+
+// LocalizedPushTemplate 多语言推送模板参数, key 为语言代码, 与 SetPushLanguage 设置的语言对应
+// 单个接收方通过 NewLocalizedTemplatePushMsg 生成推送消息, 多个接收方通过 BatchAsyncLocalizedPushNotification 按语言分组推送
+// LabelPushNotification 及 CreateFullPushTask 无法获取接收方的语言, 所有接收方使用同一个推送消息
+type LocalizedPushTemplate map[string]PushTemplateArgs
+
+// Lookup 获取指定语言的模板参数, 不存在时使用 fallback 语言的参数
+func (t LocalizedPushTemplate) Lookup(language, fallback string) (args PushTemplateArgs, ok bool) {
+	if args, ok = t[language]; ok {
+		return
+	}
+	args, ok = t[fallback]
+	return
+}
+
+// NewTemplatePushMsg 创建使用推送模板的推送消息, 可直接用于 SyncPushNotification、BatchAsyncPushNotification
+// 及 LabelPushNotification 等推送接口
+func NewTemplatePushMsg(args PushTemplateArgs) PushMsgMap {
+	return make(PushMsgMap).SetTemplate(args)
+}
+
+// NewLocalizedTemplatePushMsg 根据 username 通过 SetPushLanguage 设置的推送语言选择模板参数, 创建使用推送模板的推送消息
+// 用户未设置推送语言或模板中没有该语言时使用 fallback 语言的参数
+func (c *Client) NewLocalizedTemplatePushMsg(ctx context.Context, username string, tpl LocalizedPushTemplate, fallback string) (pushMsg PushMsgMap, err error) {
+	res, err := c.GetPushLanguage(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	args, ok := tpl.Lookup(res.Data.Language, fallback)
+	if !ok {
+		return nil, fmt.Errorf("push template for language %q and fallback %q not found", res.Data.Language, fallback)
+	}
+	return NewTemplatePushMsg(args), nil
+}
+
+// BatchAsyncLocalizedPushNotification 异步方式批量发送多语言模板推送, 一次最多100个接收方
+// 按每个接收方通过 SetPushLanguage 设置的推送语言分组, 每种语言调用一次 BatchAsyncPushNotification, 返回合并后的结果
+// 用户未设置推送语言或模板中没有该语言时使用 fallback 语言的参数; 某种语言推送失败时返回错误及已推送语言的结果
+func (c *Client) BatchAsyncLocalizedPushNotification(ctx context.Context, targets []string, tpl LocalizedPushTemplate, fallback string, strategy PushStrategy) (res *BaseRes[[]AsyncPushResultItem], err error) {
+	if len(targets) == 0 {
+		return nil, errors.New("targets is empty")
+	} else if len(targets) > 100 {
+		return nil, errors.New("targets is too much")
+	}
+	var languages []string
+	groups := make(map[string][]string)
+	for _, target := range targets {
+		langRes, err := c.GetPushLanguage(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		language := langRes.Data.Language
+		if _, ok := tpl[language]; !ok {
+			language = fallback
+		}
+		if _, ok := tpl[language]; !ok {
+			return nil, fmt.Errorf("push template for language %q and fallback %q not found", langRes.Data.Language, fallback)
+		}
+		if _, exist := groups[language]; !exist {
+			languages = append(languages, language)
+		}
+		groups[language] = append(groups[language], target)
+	}
+	res = &BaseRes[[]AsyncPushResultItem]{Data: make([]AsyncPushResultItem, 0, len(targets))}
+	for _, language := range languages {
+		groupRes, err := c.BatchAsyncPushNotification(ctx, groups[language], NewTemplatePushMsg(tpl[language]), strategy)
+		if err != nil {
+			return res, err
+		}
+		res.Timestamp, res.Duration = groupRes.Timestamp, res.Duration+groupRes.Duration
+		res.Data = append(res.Data, groupRes.Data...)
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package easemob_server_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewLocalizedTemplatePushMsg(t *testing.T) {
+	tpl := LocalizedPushTemplate{
+		"zh": {Name: "welcome_zh", ContentArgs: []string{"你好"}},
+		"en": {Name: "welcome_en", ContentArgs: []string{"hello"}},
+	}
+	for response, want := range map[string]string{
+		`{"data":{"language":"zh"}}`: "welcome_zh",
+		`{"data":{"language":"ja"}}`: "welcome_en",
+		`{"data":{"language":""}}`:   "welcome_en",
+	} {
+		handler, _ := captureHandler(response)
+		c := newTestClient(t, handler)
+		pushMsg, err := c.NewLocalizedTemplatePushMsg(context.Background(), "u1", tpl, "en")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if args := pushMsg["template"].(PushTemplateArgs); args.Name != want {
+			t.Fatalf("response %s: expected template %s, got %s", response, want, args.Name)
+		}
+	}
+	handler, _ := captureHandler(`{"data":{"language":"ja"}}`)
+	c := newTestClient(t, handler)
+	if _, err := c.NewLocalizedTemplatePushMsg(context.Background(), "u1", tpl, "fr"); err == nil {
+		t.Fatal("expected error when neither language nor fallback has a template")
+	}
+}
+
+func TestBatchAsyncLocalizedPushNotification(t *testing.T) {
+	languages := map[string]string{"u1": "zh", "u2": "en", "u3": "zh", "u4": "ja"}
+	var mu sync.Mutex
+	sent := make(map[string][]string)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			username := strings.Split(r.URL.Path, "/")[4]
+			_, _ = fmt.Fprintf(w, `{"data":{"language":%q}}`, languages[username])
+			return
+		}
+		var body struct {
+			Targets     []string `json:"targets"`
+			PushMessage struct {
+				Template PushTemplateArgs `json:"template"`
+			} `json:"pushMessage"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		items := make([]string, 0, len(body.Targets))
+		for _, target := range body.Targets {
+			items = append(items, fmt.Sprintf(`{"id":%q,"pushStatus":"SUCCESS"}`, target))
+		}
+		mu.Lock()
+		sent[body.PushMessage.Template.Name] = body.Targets
+		mu.Unlock()
+		_, _ = fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(items, ","))
+	}))
+	tpl := LocalizedPushTemplate{"zh": {Name: "welcome_zh"}, "en": {Name: "welcome_en"}}
+	res, err := c.BatchAsyncLocalizedPushNotification(context.Background(), []string{"u1", "u2", "u3", "u4"}, tpl, "en", PushStrategyThirdPartyFirst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Data) != 4 {
+		t.Fatalf("expected merged result of 4 targets, got %+v", res.Data)
+	}
+	want := map[string][]string{"welcome_zh": {"u1", "u3"}, "welcome_en": {"u2", "u4"}}
+	if !reflect.DeepEqual(sent, want) {
+		t.Fatalf("expected targets grouped by language %v, got %v", want, sent)
+	}
+}
@@ -0,0 +1,114 @@
+package easemob_server_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AddReactionResData 添加的 Reaction
+type AddReactionResData struct {
+	Id        string   `json:"id"`        // Reaction ID
+	MsgId     string   `json:"msgId"`     // 消息 ID
+	MsgType   ChatType `json:"msgType"`   // 会话类型, chat 或 groupchat
+	GroupId   string   `json:"groupId"`   // 群组 ID, 单聊时为空
+	Reaction  string   `json:"reaction"`  // 表情 ID
+	CreatedAt string   `json:"createdAt"` // 创建时间
+	UpdatedAt string   `json:"updatedAt"` // 更新时间
+}
+
+// AddReaction 以用户身份对消息添加 Reaction, 单条消息最多添加20种表情
+func (c *Client) AddReaction(ctx context.Context, username, msgId, reaction string) (res *BaseRes[AddReactionResData], err error) {
+	if len(msgId) == 0 || len(reaction) == 0 {
+		return nil, errors.New("msgId or reaction is empty")
+	}
+	data := map[string]any{"msgId": msgId, "message": reaction}
+	pathSuffix := fmt.Sprintf("reaction/user/%s", username)
+	res = new(BaseRes[AddReactionResData])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DelReaction 以用户身份删除对消息添加的 Reaction
+func (c *Client) DelReaction(ctx context.Context, username, msgId, reaction string) (res *BaseRes[string], err error) {
+	if len(msgId) == 0 || len(reaction) == 0 {
+		return nil, errors.New("msgId or reaction is empty")
+	}
+	params := map[string]any{"msgId": msgId, "message": reaction}
+	pathSuffix := fmt.Sprintf("reaction/user/%s", username)
+	res = new(BaseRes[string])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// MessageReaction 消息上的单种表情回复
+type MessageReaction struct {
+	ReactionId string   `json:"reactionId"` // Reaction ID
+	Reaction   string   `json:"reaction"`   // 表情 ID
+	Count      int      `json:"count"`      // 添加该表情的用户数
+	State      bool     `json:"state"`      // 当前用户是否添加了该表情
+	UserList   []string `json:"userList"`   // 添加该表情的用户, 列表接口最多返回3个
+}
+
+// MessageReactions 单条消息的表情回复
+type MessageReactions struct {
+	MsgId        string            `json:"msgId"`        // 消息 ID
+	ReactionList []MessageReaction `json:"reactionList"` // 表情回复列表
+}
+
+// GetReactions 获取会话中多条消息的 Reaction, 一次最多获取20条消息
+// chatType 为 ChatTypeGroupChat 时 groupId 必填
+func (c *Client) GetReactions(ctx context.Context, username string, msgIds []string, chatType ChatType, groupId string) (res *BaseRes[[]MessageReactions], err error) {
+	if len(msgIds) == 0 {
+		return nil, errors.New("msgIds is empty")
+	} else if len(msgIds) > 20 {
+		return nil, errors.New("too many msgId, maximum count is 20")
+	}
+	params := map[string]any{"msgIdList": strings.Join(msgIds, ","), "msgType": chatType}
+	switch chatType {
+	case ChatTypeChat:
+	case ChatTypeGroupChat:
+		if len(groupId) == 0 {
+			return nil, errors.New("groupId is empty")
+		}
+		params["groupId"] = groupId
+	default:
+		return nil, fmt.Errorf("unsupported chatType %s", chatType)
+	}
+	pathSuffix := fmt.Sprintf("reaction/user/%s", username)
+	res = new(BaseRes[[]MessageReactions])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetReactionUsers 分页获取对消息添加了指定表情的用户, 用户列表在 Data.UserList 中
+func (c *Client) GetReactionUsers(ctx context.Context, username, msgId, reaction string, limit int, cursor string) (res *PageRes[MessageReaction], err error) {
+	if len(msgId) == 0 || len(reaction) == 0 {
+		return nil, errors.New("msgId or reaction is empty")
+	}
+	if limit <= 0 {
+		limit = 50
+	} else if limit > 100 {
+		limit = 100
+	}
+	params := map[string]any{"msgId": msgId, "message": reaction, "limit": limit, "cursor": cursor}
+	pathSuffix := fmt.Sprintf("reaction/user/%s/detail", username)
+	resTmp := new(BaseRes[struct {
+		MessageReaction
+		Cursor string `json:"cursor"`
+	}])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, resTmp); err != nil {
+		return nil, err
+	}
+	res = &PageRes[MessageReaction]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration,
+		Cursor: resTmp.Data.Cursor, Data: resTmp.Data.MessageReaction}
+	return
+}
@@ -2,6 +2,7 @@ package easemob_server_go
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/imroc/req/v3"
 )
@@ -12,6 +13,7 @@ type ApiError struct {
 	Timestamp        int64  `json:"timestamp"`
 	Duration         int    `json:"duration"`
 	ErrorDescription string `json:"error_description"`
+	StatusCode       int    `json:"-"` // 响应的 HTTP 状态码, 如 429 表示超过接口调用频率限制
 }
 
 func (e ApiError) Error() string {
@@ -67,25 +69,57 @@ type ResultResponse struct {
 }
 
 func (c *Client) doReq(ctx context.Context, method, pathSuffix string, params map[string]any, data any, res any) (err error) {
-	r := c.reqClient.R().SetContext(ctx).SetBearerAuthToken(c.appToken)
-	if params != nil {
-		r.SetQueryParamsAnyType(params)
-	}
-	if data != nil {
-		r.SetBodyJsonMarshal(data)
-	}
-	resp, err := r.Send(method, pathSuffix)
+	return c.doAuthReq(ctx, c.reqClient, res, func(r *req.Request) (*req.Response, error) {
+		if params != nil {
+			r.SetQueryParamsAnyType(params)
+		}
+		if data != nil {
+			r.SetBodyJsonMarshal(data)
+		}
+		return r.Send(method, pathSuffix)
+	})
+}
+
+// doAuthReq 携带AppToken发送请求并解析响应, 响应为401时刷新AppToken并重试一次
+func (c *Client) doAuthReq(ctx context.Context, client *req.Client, res any, send func(r *req.Request) (*req.Response, error)) (err error) {
+	resp, err := c.sendAuthReq(ctx, client, send)
 	if err != nil {
 		return err
 	}
 	return c.parseResponse(resp, res)
 }
 
+// sendAuthReq 使用 client 携带AppToken发送请求, 响应为401时刷新AppToken并重试一次
+func (c *Client) sendAuthReq(ctx context.Context, client *req.Client, send func(r *req.Request) (*req.Response, error)) (resp *req.Response, err error) {
+	appToken, err := c.validAppToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if resp, err = send(client.R().SetContext(ctx).SetBearerAuthToken(appToken)); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+		if appToken, err = c.refreshAppToken(ctx, appToken); err != nil {
+			return nil, err
+		}
+		if resp, err = send(client.R().SetContext(ctx).SetBearerAuthToken(appToken)); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
 func (c *Client) parseResponse(resp *req.Response, res any) (err error) {
 	if resp.StatusCode != 200 {
-		var apiErr ApiError
+		apiErr := ApiError{StatusCode: resp.StatusCode}
 		if err = resp.UnmarshalJson(&apiErr); err == nil {
 			return apiErr
+		} else if resp.StatusCode == http.StatusTooManyRequests {
+			// 限流时响应体可能不是 JSON, 仍返回 ApiError 以便调用方识别状态码
+			return ApiError{StatusCode: resp.StatusCode, ErrorDescription: resp.Status}
 		}
 		return
 	}
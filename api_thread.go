@@ -0,0 +1,167 @@
+package easemob_server_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Thread 子区
+type Thread struct {
+	Id      string `json:"id"`      // 子区 ID
+	Name    string `json:"name"`    // 子区名称
+	Owner   string `json:"owner"`   // 子区创建者
+	MsgId   string `json:"msgId"`   // 创建子区的父消息 ID
+	GroupId string `json:"groupId"` // 子区所属群组 ID
+	Created int64  `json:"created"` // 创建时间, Unix 时间戳, 单位为毫秒
+}
+
+// threadPageRes 子区分页接口的原始响应, 数据在 entities 中, 游标在 properties 中
+type threadPageRes[T any] struct {
+	Timestamp  int64 `json:"timestamp"`
+	Duration   int   `json:"duration"`
+	Entities   T     `json:"entities"`
+	Properties struct {
+		Cursor string `json:"cursor"`
+	} `json:"properties"`
+}
+
+// getThreadPage 分页获取子区列表
+func (c *Client) getThreadPage(ctx context.Context, pathSuffix string, limit int, cursor string, desc bool) (res *PageRes[[]Thread], err error) {
+	if limit <= 0 {
+		limit = 10
+	} else if limit > 50 {
+		limit = 50
+	}
+	params := map[string]any{"limit": limit, "cursor": cursor, "sort": "asc"}
+	if desc {
+		params["sort"] = "desc"
+	}
+	resTmp := new(threadPageRes[[]Thread])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, resTmp); err != nil {
+		return nil, err
+	}
+	res = &PageRes[[]Thread]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration, Cursor: resTmp.Properties.Cursor, Data: resTmp.Entities}
+	return
+}
+
+type CreateThreadResData struct {
+	ThreadId string `json:"thread_id"` // 子区 ID
+}
+
+// CreateThread 基于群组消息创建子区
+func (c *Client) CreateThread(ctx context.Context, groupId, name, owner, msgId string) (res *BaseRes[CreateThreadResData], err error) {
+	if len(groupId) == 0 || len(name) == 0 || len(owner) == 0 || len(msgId) == 0 {
+		return nil, errors.New("groupId, name, owner or msgId is empty")
+	}
+	data := map[string]any{"group_id": groupId, "name": name, "owner": owner, "msg_id": msgId}
+	res = new(BaseRes[CreateThreadResData])
+	if err = c.doReq(ctx, http.MethodPost, "thread", nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type RenameThreadResData struct {
+	Name string `json:"name"` // 修改后的子区名称
+}
+
+// RenameThread 修改子区名称
+func (c *Client) RenameThread(ctx context.Context, threadId, name string) (res *BaseRes[RenameThreadResData], err error) {
+	data := map[string]any{"name": name}
+	pathSuffix := fmt.Sprintf("thread/%s", threadId)
+	res = new(BaseRes[RenameThreadResData])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type ThreadStatusResData struct {
+	Status string `json:"status"` // 操作结果, 成功时为 ok
+}
+
+// DeleteThread 删除子区
+func (c *Client) DeleteThread(ctx context.Context, threadId string) (res *BaseRes[ThreadStatusResData], err error) {
+	pathSuffix := fmt.Sprintf("thread/%s", threadId)
+	res = new(BaseRes[ThreadStatusResData])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// ListThreads 分页获取 App 下的子区, 返回的子区仅包含 ID; desc 为 true 时按创建时间倒序
+func (c *Client) ListThreads(ctx context.Context, limit int, cursor string, desc bool) (res *PageRes[[]Thread], err error) {
+	return c.getThreadPage(ctx, "thread", limit, cursor, desc)
+}
+
+// ListGroupThreads 分页获取群组下的子区; desc 为 true 时按创建时间倒序
+func (c *Client) ListGroupThreads(ctx context.Context, groupId string, limit int, cursor string, desc bool) (res *PageRes[[]Thread], err error) {
+	pathSuffix := fmt.Sprintf("threads/chatgroups/%s", groupId)
+	return c.getThreadPage(ctx, pathSuffix, limit, cursor, desc)
+}
+
+// ListUserJoinedThreads 分页获取用户在 App 下加入的子区; desc 为 true 时按加入时间倒序
+func (c *Client) ListUserJoinedThreads(ctx context.Context, username string, limit int, cursor string, desc bool) (res *PageRes[[]Thread], err error) {
+	pathSuffix := fmt.Sprintf("threads/user/%s", username)
+	return c.getThreadPage(ctx, pathSuffix, limit, cursor, desc)
+}
+
+// ListUserJoinedGroupThreads 分页获取用户在指定群组下加入的子区; desc 为 true 时按加入时间倒序
+func (c *Client) ListUserJoinedGroupThreads(ctx context.Context, groupId, username string, limit int, cursor string, desc bool) (res *PageRes[[]Thread], err error) {
+	pathSuffix := fmt.Sprintf("threads/chatgroups/%s/user/%s", groupId, username)
+	return c.getThreadPage(ctx, pathSuffix, limit, cursor, desc)
+}
+
+// GetThreadMembers 分页获取子区成员
+func (c *Client) GetThreadMembers(ctx context.Context, threadId string, limit int, cursor string) (res *PageRes[[]string], err error) {
+	if limit <= 0 {
+		limit = 10
+	} else if limit > 50 {
+		limit = 50
+	}
+	params := map[string]any{"limit": limit, "cursor": cursor}
+	pathSuffix := fmt.Sprintf("thread/%s/users", threadId)
+	resTmp := new(BaseRes[struct {
+		Affiliations []string `json:"affiliations"`
+		Properties   struct {
+			Cursor string `json:"cursor"`
+		} `json:"properties"`
+	}])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, resTmp); err != nil {
+		return nil, err
+	}
+	res = &PageRes[[]string]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration,
+		Cursor: resTmp.Data.Properties.Cursor, Data: resTmp.Data.Affiliations}
+	return
+}
+
+// AddThreadMember 批量添加子区成员, 一次最多添加10个, 用户需为子区所属群组的成员
+func (c *Client) AddThreadMember(ctx context.Context, threadId string, usernames []string) (res *BaseRes[ThreadStatusResData], err error) {
+	if len(usernames) == 0 {
+		return nil, errors.New("usernames is empty")
+	} else if len(usernames) > 10 {
+		return nil, errors.New("too many username, maximum count is 10")
+	}
+	data := map[string]any{"usernames": usernames}
+	pathSuffix := fmt.Sprintf("thread/%s/users", threadId)
+	res = new(BaseRes[ThreadStatusResData])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DelThreadMember 批量移除子区成员, 一次最多移除10个
+func (c *Client) DelThreadMember(ctx context.Context, threadId string, usernames []string) (res *BaseRes[EditGroupMemberResult], err error) {
+	if len(usernames) == 0 {
+		return nil, errors.New("usernames is empty")
+	} else if len(usernames) > 10 {
+		return nil, errors.New("too many username, maximum count is 10")
+	}
+	data := map[string]any{"usernames": usernames}
+	pathSuffix := fmt.Sprintf("thread/%s/users", threadId)
+	return c.doGroupMemberReq(ctx, http.MethodDelete, pathSuffix, data, true)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/imroc/req/v3"
 )
 
 type UserEntity struct {
@@ -14,6 +16,9 @@ type UserEntity struct {
 	Modified  int64  `json:"modified"`
 	Username  string `json:"username"`
 	Activated bool   `json:"activated"`
+
+	Nickname                 string           `json:"nickname,omitempty"`                   // 离线推送显示的昵称
+	NotificationDisplayStyle PushDisplayStyle `json:"notification_display_style,omitempty"` // 离线推送通知的展示方式
 }
 
 type NewUser struct {
@@ -205,15 +210,12 @@ func (c *Client) UserDeviceDisconnect(ctx context.Context, username, resourceId
 func (c *Client) SetUserMetadata(ctx context.Context, username string, metadata map[string]string) (res *BaseRes[map[string]string], err error) {
 	pathSuffix := fmt.Sprintf("metadata/user/%s", username)
 	res = new(BaseRes[map[string]string])
-
-	r := c.reqClient.R().SetContext(ctx).SetFormData(metadata)
-	resp, err := r.Put(pathSuffix)
+	err = c.doAuthReq(ctx, c.reqClient, res, func(r *req.Request) (*req.Response, error) {
+		return r.SetFormData(metadata).Put(pathSuffix)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if err = c.parseResponse(resp, res); err != nil {
-		return nil, err
-	}
 	return
 }
 
@@ -264,3 +266,103 @@ func (c *Client) GetAppUserMetadataCapacity(ctx context.Context) (res *BaseRes[i
 	}
 	return
 }
+
+// AddContact 添加好友
+func (c *Client) AddContact(ctx context.Context, ownerUsername, friendUsername string) (res *UserBaseRes[[]UserEntity], err error) {
+	pathSuffix := fmt.Sprintf("users/%s/contacts/users/%s", ownerUsername, friendUsername)
+	res = new(UserBaseRes[[]UserEntity])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DelContact 删除好友
+func (c *Client) DelContact(ctx context.Context, ownerUsername, friendUsername string) (res *UserBaseRes[[]UserEntity], err error) {
+	pathSuffix := fmt.Sprintf("users/%s/contacts/users/%s", ownerUsername, friendUsername)
+	res = new(UserBaseRes[[]UserEntity])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+type UserContact struct {
+	Username string `json:"username"` // 好友用户名
+	Remark   string `json:"remark"`   // 好友备注
+}
+
+// GetContactList 分页获取好友列表及好友备注
+func (c *Client) GetContactList(ctx context.Context, ownerUsername string, limit int, cursor string) (res *PageRes[[]UserContact], err error) {
+	if limit <= 0 {
+		limit = 10
+	} else if limit > 50 {
+		limit = 50
+	}
+	params := map[string]any{"limit": limit, "cursor": cursor, "needReturnRemark": true}
+	pathSuffix := fmt.Sprintf("user/%s/contacts", ownerUsername)
+	resTmp := new(BaseRes[struct {
+		Contacts []UserContact `json:"contacts"`
+		Cursor   string        `json:"cursor"`
+	}])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, resTmp); err != nil {
+		return nil, err
+	}
+	res = &PageRes[[]UserContact]{Timestamp: resTmp.Timestamp, Duration: resTmp.Duration,
+		Cursor: resTmp.Data.Cursor, Data: resTmp.Data.Contacts}
+	return
+}
+
+// SetContactRemark 设置好友备注, 最大长度为100字符
+func (c *Client) SetContactRemark(ctx context.Context, ownerUsername, friendUsername, remark string) (res *UserBaseRes[any], err error) {
+	data := map[string]string{"remark": remark}
+	pathSuffix := fmt.Sprintf("user/%s/contacts/users/%s", ownerUsername, friendUsername)
+	res = new(UserBaseRes[any])
+	if err = c.doReq(ctx, http.MethodPut, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// AddUserBlock 将用户加入黑名单, 一次最多添加50个, 黑名单中的用户无法向该用户发送消息
+// 返回结果 Data 为加入黑名单成功的用户列表
+func (c *Client) AddUserBlock(ctx context.Context, ownerUsername string, usernames []string) (res *BaseRes[[]string], err error) {
+	if len(usernames) == 0 {
+		return nil, errors.New("minimum count of username is 1")
+	} else if len(usernames) > 50 {
+		return nil, errors.New("maximum count of username is 50")
+	}
+	data := map[string]any{"usernames": usernames}
+	pathSuffix := fmt.Sprintf("users/%s/blocks/users", ownerUsername)
+	res = new(BaseRes[[]string])
+	if err = c.doReq(ctx, http.MethodPost, pathSuffix, nil, data, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// DelUserBlock 将用户移出黑名单
+func (c *Client) DelUserBlock(ctx context.Context, ownerUsername, blockedUsername string) (res *UserBaseRes[[]UserEntity], err error) {
+	pathSuffix := fmt.Sprintf("users/%s/blocks/users/%s", ownerUsername, blockedUsername)
+	res = new(UserBaseRes[[]UserEntity])
+	if err = c.doReq(ctx, http.MethodDelete, pathSuffix, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetUserBlockList 分页获取黑名单列表
+func (c *Client) GetUserBlockList(ctx context.Context, ownerUsername string, pageSize int, cursor string) (res *PageRes[[]string], err error) {
+	if pageSize <= 0 {
+		pageSize = 10
+	} else if pageSize > 50 {
+		pageSize = 50
+	}
+	params := map[string]any{"pageSize": pageSize, "cursor": cursor}
+	pathSuffix := fmt.Sprintf("users/%s/blocks/users", ownerUsername)
+	res = new(PageRes[[]string])
+	if err = c.doReq(ctx, http.MethodGet, pathSuffix, params, nil, res); err != nil {
+		return nil, err
+	}
+	return
+}
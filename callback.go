@@ -0,0 +1,473 @@
+package easemob_server_go
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxCallbackBodySize   = 4 << 20         // 回调请求体的最大长度
+	defaultCallbackMaxAge = defaultDedupTTL // 回调时间与当前时间的最大差值, 不超过去重记录的有效期
+)
+
+// CallbackBase 回调事件的公共字段
+type CallbackBase struct {
+	CallId          string `json:"callId"`          // 回调 ID, 格式为 {appkey}_{uuid}
+	EventType       string `json:"eventType"`       // 事件类型, 消息为 chat 或 chat_offline, 用户在线状态为 userStatus
+	Timestamp       int64  `json:"timestamp"`       // 事件时间, Unix 时间戳, 单位为毫秒
+	ChatType        string `json:"chat_type"`       // 会话类型, 如 chat、groupchat、chatroom、recall、reaction、muc、roster
+	GroupId         string `json:"group_id"`        // 群组或聊天室 ID, 单聊时为空
+	From            string `json:"from"`            // 发送方
+	To              string `json:"to"`              // 接收方
+	MsgId           string `json:"msg_id"`          // 消息 ID
+	AppKey          string `json:"appkey"`          // App Key
+	Host            string `json:"host"`            // 服务器名称
+	Security        string `json:"security"`        // 签名, 为 MD5(callId + secret + timestamp)
+	SecurityVersion string `json:"securityVersion"` // 签名版本
+}
+
+func (b *CallbackBase) Base() *CallbackBase { return b }
+
+// EventKey 事件的去重键, 带 msg_id 的事件由 eventType、chat_type 及 msg_id 组成
+// 同一消息的 chat 与 chat_offline 等不同事件互不影响; 用户在线状态等不带 msg_id 的事件使用 callId
+// 重试的回调与首次回调的 callId 相同
+func (b *CallbackBase) EventKey() string {
+	if len(b.MsgId) > 0 {
+		return fmt.Sprintf("%s:%s:%s", b.EventType, b.ChatType, b.MsgId)
+	}
+	return b.CallId
+}
+
+// CallbackEvent 回调事件, 具体类型为 *MessageEvent、*RecallEvent、*ReactionEvent、*UserStatusEvent、
+// *MucEvent、*RosterEvent 或 *UnknownEvent
+type CallbackEvent interface {
+	Base() *CallbackBase
+}
+
+// MessageEvent 消息事件, 发送前回调及发送后回调的单聊、群聊、聊天室消息
+type MessageEvent struct {
+	CallbackBase
+	Payload HistoryMessagePayload `json:"payload"` // 消息内容
+}
+
+// Offline 接收方是否离线
+func (e *MessageEvent) Offline() bool { return e.EventType == "chat_offline" }
+
+// RecallEvent 撤回消息事件
+type RecallEvent struct {
+	CallbackBase
+	Payload struct {
+		RecallId string         `json:"recall_id"` // 被撤回的消息 ID
+		Ext      map[string]any `json:"ext"`       // 消息扩展字段
+	} `json:"payload"`
+}
+
+// ReactionEvent 消息表情回复事件
+type ReactionEvent struct {
+	CallbackBase
+	Payload struct {
+		MessageId string `json:"messageId"` // 被回复的消息 ID
+		Reaction  string `json:"reaction"`  // 表情 ID
+		Operation string `json:"operation"` // 操作类型, add 或 remove
+	} `json:"payload"`
+}
+
+// UserStatusEvent 用户登录、登出事件
+type UserStatusEvent struct {
+	CallbackBase
+	User    string `json:"user"`    // 用户, 格式为 {appkey}_{username}/{resource}
+	Status  string `json:"status"`  // 在线状态, online 或 offline
+	Reason  string `json:"reason"`  // 状态变化原因, 如 login、logout、replaced、kicked
+	Os      string `json:"os"`      // 设备系统
+	Ip      string `json:"ip"`      // 设备 IP
+	Version string `json:"version"` // SDK 版本
+}
+
+// CallbackOpStatus 操作结果
+type CallbackOpStatus struct {
+	Description string `json:"description"` // 结果描述
+	ErrorCode   string `json:"error_code"`  // 错误码, 成功时为 ok
+}
+
+// MucEvent 群组及聊天室事件
+type MucEvent struct {
+	CallbackBase
+	Payload struct {
+		MucId      string           `json:"muc_id"`      // 群组或聊天室 ID, 格式为 {appkey}_{id}@conference.easemob.com
+		Operation  string           `json:"operation"`   // 操作类型, 如 create、destroy、join、leave、kick、ban、update、add_admin、remove_admin
+		Reason     string           `json:"reason"`      // 操作原因
+		IsChatroom bool             `json:"is_chatroom"` // 是否为聊天室
+		Status     CallbackOpStatus `json:"status"`      // 操作结果
+	} `json:"payload"`
+}
+
+// RosterEvent 好友关系事件
+type RosterEvent struct {
+	CallbackBase
+	Payload struct {
+		Operation string           `json:"operation"`  // 操作类型, 如 add、remove、accept、decline、ban、allow
+		RosterVer string           `json:"roster_ver"` // 好友列表版本号
+		Status    CallbackOpStatus `json:"status"`     // 操作结果
+	} `json:"payload"`
+}
+
+// UnknownEvent 未知类型的事件, 保留原始内容
+type UnknownEvent struct {
+	CallbackBase
+	Raw json.RawMessage `json:"-"`
+}
+
+// decodeCallbackEvent 根据 eventType 及 chat_type 解析回调事件
+func decodeCallbackEvent(base *CallbackBase, raw []byte) (event CallbackEvent, err error) {
+	if base.EventType == "userStatus" {
+		return decodeEvent[UserStatusEvent](raw)
+	}
+	switch base.ChatType {
+	case string(ChatTypeChat), string(ChatTypeGroupChat), string(ChatTypeChatroom):
+		return decodeEvent[MessageEvent](raw)
+	case "recall":
+		return decodeEvent[RecallEvent](raw)
+	case "reaction":
+		return decodeEvent[ReactionEvent](raw)
+	case "muc":
+		return decodeEvent[MucEvent](raw)
+	case "roster":
+		return decodeEvent[RosterEvent](raw)
+	}
+	return &UnknownEvent{CallbackBase: *base, Raw: raw}, nil
+}
+
+func decodeEvent[T any, PT interface {
+	*T
+	CallbackEvent
+}](raw []byte) (CallbackEvent, error) {
+	event := PT(new(T))
+	if err := json.Unmarshal(raw, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// readCallback 读取回调请求并校验签名及回调时间, 失败时返回应响应的 HTTP 状态码
+// 回调时间与当前时间相差超过 maxAge 时拒绝, 防止签名有效的回调在去重记录失效后被重放
+func readCallback(r *http.Request, secret string, maxAge time.Duration) (base *CallbackBase, raw []byte, status int, err error) {
+	if r.Method != http.MethodPost {
+		return nil, nil, http.StatusMethodNotAllowed, fmt.Errorf("unexpected method %s", r.Method)
+	}
+	if raw, err = io.ReadAll(io.LimitReader(r.Body, maxCallbackBodySize)); err != nil {
+		return nil, nil, http.StatusBadRequest, err
+	}
+	base = new(CallbackBase)
+	if err = json.Unmarshal(raw, base); err != nil {
+		return nil, nil, http.StatusBadRequest, err
+	}
+	if !VerifyCallbackSignature(secret, base) {
+		return nil, nil, http.StatusUnauthorized, errors.New("invalid callback signature")
+	}
+	if age := time.Since(time.UnixMilli(base.Timestamp)); age > maxAge || age < -maxAge {
+		return nil, nil, http.StatusUnauthorized, fmt.Errorf("callback timestamp %d is outside the allowed window", base.Timestamp)
+	}
+	return base, raw, http.StatusOK, nil
+}
+
+// VerifyCallbackSignature 校验回调签名, secret 为控制台中回调规则配置的密钥
+// 仅校验签名, 自行处理回调时还需校验 Timestamp 与当前时间的差值, 防止回调被重放
+func VerifyCallbackSignature(secret string, base *CallbackBase) bool {
+	sum := md5.Sum([]byte(base.CallId + secret + strconv.FormatInt(base.Timestamp, 10)))
+	expected := hex.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(strings.ToLower(base.Security))) == 1
+}
+
+// CallbackHandler 接收发送后回调的 http.Handler, 按事件类型分发到注册的处理函数
+// 处理函数返回错误时响应 500, 环信会重试该回调; 默认使用 MemoryDedupStore 去重, 同一事件在有效期内只处理一次
+// 事件处理完成前收到的重试回调响应 409, 环信会稍后再次重试
+type CallbackHandler struct {
+	secret     string
+	logger     Logger
+	maxAge     time.Duration
+	dedupStore DedupStore
+	onEvent    func(ctx context.Context, event CallbackEvent) error
+	onMessage  func(ctx context.Context, event *MessageEvent) error
+	onRecall   func(ctx context.Context, event *RecallEvent) error
+	onReaction func(ctx context.Context, event *ReactionEvent) error
+	onStatus   func(ctx context.Context, event *UserStatusEvent) error
+	onMuc      func(ctx context.Context, event *MucEvent) error
+	onRoster   func(ctx context.Context, event *RosterEvent) error
+	onUnknown  func(ctx context.Context, event *UnknownEvent) error
+}
+
+// NewCallbackHandler 创建发送后回调的 http.Handler, secret 为控制台中回调规则配置的密钥
+func NewCallbackHandler(secret string) *CallbackHandler {
+	return &CallbackHandler{secret: secret, maxAge: defaultCallbackMaxAge,
+		dedupStore: NewMemoryDedupStore(defaultDedupCapacity, defaultDedupTTL)}
+}
+
+// SetDedupStore 设置回调事件去重存储, 为 nil 时不去重
+// 去重记录的有效期需不短于 SetMaxEventAge 设置的时长, 否则过期的回调可能被重复处理
+func (h *CallbackHandler) SetDedupStore(store DedupStore) *CallbackHandler {
+	h.dedupStore = store
+	return h
+}
+
+// SetMaxEventAge 设置回调时间与当前时间的最大差值, 超出时拒绝该回调, 默认为10分钟
+// 使用 MemoryDedupStore 时实际生效的时长不超过其记录的有效期
+func (h *CallbackHandler) SetMaxEventAge(maxAge time.Duration) *CallbackHandler {
+	if maxAge > 0 {
+		h.maxAge = maxAge
+	}
+	return h
+}
+
+// eventMaxAge 返回回调时间的最大差值, 不超过 MemoryDedupStore 记录的有效期
+func (h *CallbackHandler) eventMaxAge() time.Duration {
+	if store, ok := h.dedupStore.(*MemoryDedupStore); ok {
+		return min(h.maxAge, store.ttl)
+	}
+	return h.maxAge
+}
+
+// OnEvent 注册事件的默认处理函数, 未注册对应类型处理函数的事件由 fn 处理
+func (h *CallbackHandler) OnEvent(fn func(ctx context.Context, event CallbackEvent) error) *CallbackHandler {
+	h.onEvent = fn
+	return h
+}
+
+// SetLogger 设置记录回调处理失败的日志
+func (h *CallbackHandler) SetLogger(logger Logger) *CallbackHandler {
+	h.logger = logger
+	return h
+}
+
+// OnMessage 注册消息事件的处理函数
+func (h *CallbackHandler) OnMessage(fn func(ctx context.Context, event *MessageEvent) error) *CallbackHandler {
+	h.onMessage = fn
+	return h
+}
+
+// OnRecall 注册撤回消息事件的处理函数
+func (h *CallbackHandler) OnRecall(fn func(ctx context.Context, event *RecallEvent) error) *CallbackHandler {
+	h.onRecall = fn
+	return h
+}
+
+// OnReaction 注册消息表情回复事件的处理函数
+func (h *CallbackHandler) OnReaction(fn func(ctx context.Context, event *ReactionEvent) error) *CallbackHandler {
+	h.onReaction = fn
+	return h
+}
+
+// OnUserStatus 注册用户登录、登出事件的处理函数
+func (h *CallbackHandler) OnUserStatus(fn func(ctx context.Context, event *UserStatusEvent) error) *CallbackHandler {
+	h.onStatus = fn
+	return h
+}
+
+// OnMuc 注册群组及聊天室事件的处理函数
+func (h *CallbackHandler) OnMuc(fn func(ctx context.Context, event *MucEvent) error) *CallbackHandler {
+	h.onMuc = fn
+	return h
+}
+
+// OnRoster 注册好友关系事件的处理函数
+func (h *CallbackHandler) OnRoster(fn func(ctx context.Context, event *RosterEvent) error) *CallbackHandler {
+	h.onRoster = fn
+	return h
+}
+
+// OnUnknown 注册未知类型事件的处理函数
+func (h *CallbackHandler) OnUnknown(fn func(ctx context.Context, event *UnknownEvent) error) *CallbackHandler {
+	h.onUnknown = fn
+	return h
+}
+
+func (h *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	base, raw, status, err := readCallback(r, h.secret, h.eventMaxAge())
+	if err != nil {
+		h.fail(w, status, err)
+		return
+	}
+	event, err := decodeCallbackEvent(base, raw)
+	if err != nil {
+		h.fail(w, http.StatusBadRequest, err)
+		return
+	}
+	ctx, key := r.Context(), event.Base().EventKey()
+	dedup := h.dedupStore != nil && len(key) > 0
+	if dedup {
+		state, err := h.dedupStore.Begin(ctx, key)
+		if err != nil {
+			h.fail(w, http.StatusInternalServerError, err)
+			return
+		}
+		switch state {
+		case DedupStateDone:
+			w.WriteHeader(http.StatusOK)
+			return
+		case DedupStateProcessing:
+			h.fail(w, http.StatusConflict, fmt.Errorf("callback %s is being processed", key))
+			return
+		}
+		// 处理函数 panic 时同样删除处理中的标记, 使重试的回调可以再次被处理
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				h.abort(ctx, key)
+				panic(recovered)
+			}
+		}()
+	}
+	if err = h.dispatch(ctx, event); err != nil {
+		if dedup {
+			h.abort(ctx, key)
+		}
+		h.fail(w, http.StatusInternalServerError, err)
+		return
+	}
+	if dedup {
+		if err = h.dedupStore.Commit(context.WithoutCancel(ctx), key); err != nil && h.logger != nil {
+			h.logger.Warnf("easemob callback commit %s failed: %v", key, err)
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// abort 删除事件处理中的标记
+func (h *CallbackHandler) abort(ctx context.Context, key string) {
+	if err := h.dedupStore.Abort(context.WithoutCancel(ctx), key); err != nil && h.logger != nil {
+		h.logger.Warnf("easemob callback abort %s failed: %v", key, err)
+	}
+}
+
+// dispatch 将事件分发到对应的处理函数, 未注册处理函数时交给 onEvent, 均未注册时忽略
+func (h *CallbackHandler) dispatch(ctx context.Context, event CallbackEvent) error {
+	switch e := event.(type) {
+	case *MessageEvent:
+		if h.onMessage != nil {
+			return h.onMessage(ctx, e)
+		}
+	case *RecallEvent:
+		if h.onRecall != nil {
+			return h.onRecall(ctx, e)
+		}
+	case *ReactionEvent:
+		if h.onReaction != nil {
+			return h.onReaction(ctx, e)
+		}
+	case *UserStatusEvent:
+		if h.onStatus != nil {
+			return h.onStatus(ctx, e)
+		}
+	case *MucEvent:
+		if h.onMuc != nil {
+			return h.onMuc(ctx, e)
+		}
+	case *RosterEvent:
+		if h.onRoster != nil {
+			return h.onRoster(ctx, e)
+		}
+	case *UnknownEvent:
+		if h.onUnknown != nil {
+			return h.onUnknown(ctx, e)
+		}
+	}
+	if h.onEvent != nil {
+		return h.onEvent(ctx, event)
+	}
+	return nil
+}
+
+func (h *CallbackHandler) fail(w http.ResponseWriter, status int, err error) {
+	if h.logger != nil {
+		h.logger.Errorf("easemob callback failed: %v", err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// PreSendResult 发送前回调的处理结果
+type PreSendResult struct {
+	Valid   bool           `json:"valid"`             // 是否允许发送
+	Code    string         `json:"code,omitempty"`    // 拒绝发送的原因, 会透传给发送方
+	Payload map[string]any `json:"payload,omitempty"` // 修改后的消息内容, 为空时按原消息发送
+}
+
+// PreSendAllow 允许按原消息发送
+func PreSendAllow() PreSendResult {
+	return PreSendResult{Valid: true}
+}
+
+// PreSendReject 拒绝发送, code 会透传给发送方
+func PreSendReject(code string) PreSendResult {
+	return PreSendResult{Valid: false, Code: code}
+}
+
+// PreSendRewrite 允许发送并替换消息内容, ext 为 nil 时消息不带扩展字段
+func PreSendRewrite(bodies []MessageBody, ext map[string]any) (PreSendResult, error) {
+	rawBodies := make([]json.RawMessage, 0, len(bodies))
+	for _, body := range bodies {
+		if body == nil {
+			return PreSendResult{}, errors.New("message body is empty")
+		}
+		raw, err := marshalWithExtra(body, map[string]any{"type": body.MessageType()})
+		if err != nil {
+			return PreSendResult{}, err
+		}
+		rawBodies = append(rawBodies, raw)
+	}
+	payload := map[string]any{"bodies": rawBodies}
+	if ext != nil {
+		payload["ext"] = ext
+	}
+	return PreSendResult{Valid: true, Payload: payload}, nil
+}
+
+// PreSendHandler 接收发送前回调的 http.Handler, 由 fn 决定消息是否发送及发送的内容
+type PreSendHandler struct {
+	secret string
+	logger Logger
+	fn     func(ctx context.Context, event *MessageEvent) PreSendResult
+}
+
+// NewPreSendHandler 创建发送前回调的 http.Handler, secret 为控制台中回调规则配置的密钥
+// fn 不能为 nil
+func NewPreSendHandler(secret string, fn func(ctx context.Context, event *MessageEvent) PreSendResult) *PreSendHandler {
+	if fn == nil {
+		panic("`fn` must not be nil")
+	}
+	return &PreSendHandler{secret: secret, fn: fn}
+}
+
+// SetLogger 设置记录回调处理失败的日志
+func (h *PreSendHandler) SetLogger(logger Logger) *PreSendHandler {
+	h.logger = logger
+	return h
+}
+
+func (h *PreSendHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_, raw, status, err := readCallback(r, h.secret, defaultCallbackMaxAge)
+	if err != nil {
+		if h.logger != nil {
+			h.logger.Errorf("easemob pre-send callback failed: %v", err)
+		}
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+	event := new(MessageEvent)
+	if err = json.Unmarshal(raw, event); err != nil {
+		if h.logger != nil {
+			h.logger.Errorf("easemob pre-send callback failed: %v", err)
+		}
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.fn(r.Context(), event))
+}
@@ -0,0 +1,158 @@
+package easemob_server_go
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testCallbackSecret = "secret"
+
+// signedCallback 返回带有效签名的回调请求体
+func signedCallback(t *testing.T, fields map[string]any, timestamp time.Time) string {
+	t.Helper()
+	callId := "org#app_" + strconv.FormatInt(timestamp.UnixNano(), 10)
+	if id, ok := fields["callId"].(string); ok {
+		callId = id
+	}
+	sum := md5.Sum([]byte(callId + testCallbackSecret + strconv.FormatInt(timestamp.UnixMilli(), 10)))
+	body := map[string]any{"callId": callId, "timestamp": timestamp.UnixMilli(), "security": hex.EncodeToString(sum[:])}
+	for key, val := range fields {
+		body[key] = val
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func postCallback(h http.Handler, body string) int {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body)))
+	return w.Code
+}
+
+func TestCallbackHandlerDedup(t *testing.T) {
+	var online, offline atomic.Int32
+	h := NewCallbackHandler(testCallbackSecret).OnMessage(func(ctx context.Context, event *MessageEvent) error {
+		if event.Offline() {
+			offline.Add(1)
+		} else {
+			online.Add(1)
+		}
+		return nil
+	})
+	now := time.Now()
+	chat := signedCallback(t, map[string]any{"callId": "c1", "eventType": "chat", "chat_type": "chat", "msg_id": "m1"}, now)
+	chatOffline := signedCallback(t, map[string]any{"callId": "c2", "eventType": "chat_offline", "chat_type": "chat", "msg_id": "m1"}, now)
+	for _, body := range []string{chat, chat, chatOffline, chatOffline} {
+		if code := postCallback(h, body); code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", code)
+		}
+	}
+	if online.Load() != 1 || offline.Load() != 1 {
+		t.Fatalf("expected each event type handled once, got chat=%d chat_offline=%d", online.Load(), offline.Load())
+	}
+}
+
+func TestCallbackHandlerRetryDuringProcessing(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	var calls atomic.Int32
+	h := NewCallbackHandler(testCallbackSecret).OnUserStatus(func(ctx context.Context, event *UserStatusEvent) error {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-release
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+	body := signedCallback(t, map[string]any{"eventType": "userStatus", "status": "online"}, time.Now())
+	first := make(chan int)
+	go func() { first <- postCallback(h, body) }()
+	<-started
+	if code := postCallback(h, body); code != http.StatusConflict {
+		t.Fatalf("expected retry during processing to get 409, got %d", code)
+	}
+	close(release)
+	if code := <-first; code != http.StatusInternalServerError {
+		t.Fatalf("expected failed dispatch to get 500, got %d", code)
+	}
+	if code := postCallback(h, body); code != http.StatusOK {
+		t.Fatalf("expected retry after failure to be processed, got %d", code)
+	}
+	if code := postCallback(h, body); code != http.StatusOK || calls.Load() != 2 {
+		t.Fatalf("expected committed event skipped, got %d with %d calls", code, calls.Load())
+	}
+}
+
+func TestCallbackHandlerPanicAbortsEvent(t *testing.T) {
+	var calls atomic.Int32
+	h := NewCallbackHandler(testCallbackSecret).OnUserStatus(func(ctx context.Context, event *UserStatusEvent) error {
+		if calls.Add(1) == 1 {
+			panic("handler bug")
+		}
+		return nil
+	})
+	body := signedCallback(t, map[string]any{"eventType": "userStatus", "status": "online"}, time.Now())
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected handler panic to propagate")
+			}
+		}()
+		postCallback(h, body)
+	}()
+	if code := postCallback(h, body); code != http.StatusOK || calls.Load() != 2 {
+		t.Fatalf("expected retry after panic to be processed, got %d with %d calls", code, calls.Load())
+	}
+}
+
+func TestCallbackHandlerTimestampWindow(t *testing.T) {
+	var calls atomic.Int32
+	h := NewCallbackHandler(testCallbackSecret).SetMaxEventAge(time.Minute).
+		OnEvent(func(ctx context.Context, event CallbackEvent) error {
+			calls.Add(1)
+			return nil
+		})
+	for _, timestamp := range []time.Time{time.Now().Add(-2 * time.Minute), time.Now().Add(2 * time.Minute)} {
+		body := signedCallback(t, map[string]any{"eventType": "userStatus"}, timestamp)
+		if code := postCallback(h, body); code != http.StatusUnauthorized {
+			t.Fatalf("expected callback outside window rejected, got %d", code)
+		}
+	}
+	// 窗口不超过去重记录的有效期
+	h.SetDedupStore(NewMemoryDedupStore(10, 10*time.Second))
+	body := signedCallback(t, map[string]any{"eventType": "userStatus"}, time.Now().Add(-30*time.Second))
+	if code := postCallback(h, body); code != http.StatusUnauthorized {
+		t.Fatalf("expected window clamped to dedup ttl, got %d", code)
+	}
+	body = signedCallback(t, map[string]any{"eventType": "userStatus"}, time.Now())
+	if code := postCallback(h, body); code != http.StatusOK || calls.Load() != 1 {
+		t.Fatalf("expected fresh callback handled, got %d with %d calls", code, calls.Load())
+	}
+}
+
+func TestNewPreSendHandlerNilFn(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for nil fn")
+		}
+	}()
+	NewPreSendHandler(testCallbackSecret, nil)
+}
+
+func TestPreSendRewriteNilBody(t *testing.T) {
+	if _, err := PreSendRewrite([]MessageBody{TextMessageBody{Msg: "hi"}, nil}, nil); err == nil {
+		t.Fatal("expected error for nil message body")
+	}
+}
@@ -1,9 +1,14 @@
 package easemob_server_go
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"github.com/imroc/req/v3"
+	"net/http"
+	"net/url"
 	"path"
+	"sync"
 	"time"
 )
 
@@ -15,22 +20,183 @@ type Client struct {
 
 	clientId     string
 	clientSecret string
-	appToken     string
 
-	reqClient *req.Client
+	tokenMu          sync.RWMutex
+	appToken         string
+	appTokenExpireAt time.Time // 零值表示 token 不会过期
+	appTokenTTL      int64     // 自动获取 AppToken 时使用的 ttl, 含义同 GetAppToken
+	ttlInMillisecond bool      // VIP5集群 ttl 及 expires_in 单位为毫秒
+	tokenStore       TokenStore
+	tokenOwner       string        // 当前节点在 TokenStore 中持有租约时使用的标识
+	tokenRefresh     *tokenRefresh // 进行中的AppToken刷新, 由 tokenMu 保护
+
+	reqClient      *req.Client
+	transferClient *req.Client // 用于文件上传下载, 不设置超时, 由调用方的 ctx 控制
+}
+
+// Logger 日志接口, 用于输出请求过程中的调试及错误信息
+type Logger interface {
+	Errorf(format string, v ...any)
+	Warnf(format string, v ...any)
+	Debugf(format string, v ...any)
+}
+
+type options struct {
+	scheme           string
+	userAgent        string
+	timeout          time.Duration
+	retryCount       int
+	retryBackoffMin  time.Duration
+	retryBackoffMax  time.Duration
+	maxConnsPerHost  int
+	httpClient       *http.Client
+	transport        http.RoundTripper
+	proxyUrl         string
+	logger           Logger
+	tokenStore       TokenStore
+	appTokenTTL      int64
+	ttlInMillisecond bool
+	devMode          bool
+}
+
+// Option Client 配置项
+type Option func(o *options)
+
+// WithScheme 设置请求协议, 默认为 https, 本地模拟服务可设置为 http
+func WithScheme(scheme string) Option {
+	return func(o *options) { o.scheme = scheme }
+}
+
+// WithUserAgent 设置请求的 User-Agent, 默认为 easemob-server-go
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) { o.userAgent = userAgent }
+}
+
+// WithTimeout 设置单次请求超时时间, 默认为6秒
+// 文件上传下载不受该超时限制, 需通过 ctx 控制超时
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithRetry 设置请求失败后的重试次数及重试间隔, 默认重试2次且无间隔
+// 重试间隔在 backoffMin 与 backoffMax 之间指数退避, 均为0时立即重试
+func WithRetry(count int, backoffMin, backoffMax time.Duration) Option {
+	return func(o *options) { o.retryCount, o.retryBackoffMin, o.retryBackoffMax = count, backoffMin, backoffMax }
+}
+
+// WithMaxConnsPerHost 设置每个 host 的最大连接数, 默认为5
+func WithMaxConnsPerHost(maxConns int) Option {
+	return func(o *options) { o.maxConnsPerHost = maxConns }
+}
+
+// WithHTTPClient 使用自定义的 http.Client 发送请求, 将使用其 Transport、Timeout 及 Jar
+// Transport 的限制同 WithTransport
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) { o.httpClient = httpClient }
+}
+
+// WithTransport 使用自定义的 http.RoundTripper 发送请求
+// transport 为 *http.Transport 时 WithProxy 作用于其副本; 其他类型的 transport 需自行处理代理, WithProxy 不生效
+// 使用自定义 transport 时 WithDevMode 仅输出调试日志, 不输出请求及响应详情
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) { o.transport = transport }
+}
+
+// WithProxy 设置代理地址, 如 http://127.0.0.1:8080、socks5://127.0.0.1:1080
+func WithProxy(proxyUrl string) Option {
+	return func(o *options) { o.proxyUrl = proxyUrl }
+}
+
+// WithLogger 设置日志输出
+func WithLogger(logger Logger) Option {
+	return func(o *options) { o.logger = logger }
+}
+
+// WithTokenStore 设置 AppToken 共享存储, 见 SetTokenStore
+func WithTokenStore(store TokenStore) Option {
+	return func(o *options) { o.tokenStore = store }
+}
+
+// WithAppTokenTTL 设置自动获取AppToken时使用的ttl, 见 SetAppTokenTTL
+func WithAppTokenTTL(ttl int64) Option {
+	return func(o *options) { o.appTokenTTL = ttl }
+}
+
+// WithTTLInMillisecond 设置 ttl 及 expires_in 的单位为毫秒, VIP5集群需开启
+func WithTTLInMillisecond() Option {
+	return func(o *options) { o.ttlInMillisecond = true }
+}
+
+// WithDevMode 开启调试模式, 输出请求及响应详情
+func WithDevMode(devMode bool) Option {
+	return func(o *options) { o.devMode = devMode }
 }
 
 func New(host, orgName, appName, clientId, clientSecret string, devMode bool) (client *Client) {
-	baseUrl := fmt.Sprintf("https://%s/%s", host, path.Join(orgName, appName))
-	reqClient := req.C().SetBaseURL(baseUrl).SetUserAgent("easemob-server-go")
-	reqClient.SetCommonRetryCount(2).SetMaxConnsPerHost(5)
-	reqClient.SetTimeout(6 * time.Second)
+	return NewWithOptions(host, orgName, appName, clientId, clientSecret, WithDevMode(devMode))
+}
+
+func NewWithOptions(host, orgName, appName, clientId, clientSecret string, opts ...Option) (client *Client) {
+	o := &options{scheme: "https", userAgent: "easemob-server-go", timeout: 6 * time.Second,
+		retryCount: 2, maxConnsPerHost: 5, appTokenTTL: -1}
+	for _, opt := range opts {
+		opt(o)
+	}
+	baseUrl := fmt.Sprintf("%s://%s/%s", o.scheme, host, path.Join(orgName, appName))
+	reqClient := req.C().SetBaseURL(baseUrl).SetUserAgent(o.userAgent)
+	reqClient.SetCommonRetryCount(o.retryCount).SetMaxConnsPerHost(o.maxConnsPerHost)
+	if o.retryBackoffMax > 0 {
+		reqClient.SetCommonRetryBackoffInterval(o.retryBackoffMin, o.retryBackoffMax)
+	}
+	reqClient.SetTimeout(o.timeout)
 	reqClient.SetIdleConnTimeout(60 * time.Minute)
 	reqClient.SetExpectContinueTimeout(2 * time.Second)
-	if devMode {
+	if o.httpClient != nil {
+		if o.httpClient.Transport != nil && o.transport == nil {
+			o.transport = o.httpClient.Transport
+		}
+		if o.httpClient.Timeout > 0 {
+			reqClient.SetTimeout(o.httpClient.Timeout)
+		}
+		if o.httpClient.Jar != nil {
+			reqClient.SetCookieJar(o.httpClient.Jar)
+		}
+	}
+	if o.logger != nil {
+		reqClient.SetLogger(o.logger)
+	}
+	if o.transport != nil {
+		transport := o.transport
+		if httpTransport, ok := transport.(*http.Transport); ok && len(o.proxyUrl) > 0 {
+			if proxyUrl, err := url.Parse(o.proxyUrl); err != nil {
+				reqClient.GetLogger().Errorf("failed to parse proxy url %s: %v", o.proxyUrl, err)
+			} else {
+				httpTransport = httpTransport.Clone()
+				httpTransport.Proxy = http.ProxyURL(proxyUrl)
+				transport = httpTransport
+			}
+		} else if len(o.proxyUrl) > 0 {
+			reqClient.GetLogger().Warnf("proxy %s is ignored by custom transport %T", o.proxyUrl, transport)
+		}
+		if o.devMode {
+			reqClient.GetLogger().Warnf("request dump of dev mode is not supported by custom transport %T", transport)
+		}
+		reqClient.GetTransport().WrapRoundTripFunc(func(http.RoundTripper) req.HttpRoundTripFunc {
+			return transport.RoundTrip
+		})
+	} else if len(o.proxyUrl) > 0 {
+		reqClient.SetProxyURL(o.proxyUrl)
+	}
+	if o.devMode {
 		reqClient.DevMode()
 	}
-	client = &Client{reqClient: reqClient, appKey: fmt.Sprintf("%s#%s", orgName, appName),
-		host: host, orgName: orgName, appName: appName, clientId: clientId, clientSecret: clientSecret}
+	// 流式传输的耗时与文件大小相关, http.Client.Timeout 会在传输途中中断请求
+	transferClient := reqClient.Clone().SetTimeout(0)
+	ownerBytes := make([]byte, 8)
+	_, _ = rand.Read(ownerBytes)
+	client = &Client{reqClient: reqClient, transferClient: transferClient, appKey: fmt.Sprintf("%s#%s", orgName, appName),
+		host: host, orgName: orgName, appName: appName, clientId: clientId, clientSecret: clientSecret,
+		appTokenTTL: o.appTokenTTL, ttlInMillisecond: o.ttlInMillisecond, tokenStore: o.tokenStore,
+		tokenOwner: hex.EncodeToString(ownerBytes)}
 	return
 }
@@ -0,0 +1,44 @@
+package easemob_server_go
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestClient 返回访问本地模拟服务的 Client, 服务端由 handler 处理所有请求
+// Client 不重试失败的请求, 并预设 AppToken 为 "token"
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	opts = append([]Option{WithScheme("http"), WithRetry(0, 0, 0)}, opts...)
+	c := NewWithOptions(strings.TrimPrefix(server.URL, "http://"), "org", "app", "id", "secret", opts...)
+	c.SetAppToken("token")
+	return c
+}
+
+func TestCustomTransportUsesProxy(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 经过代理的请求行为绝对地址
+		if r.URL.Host == "easemob.invalid" {
+			proxied.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","expires_in":0}`))
+	}))
+	defer proxy.Close()
+
+	c := NewWithOptions("easemob.invalid", "org", "app", "id", "secret", WithScheme("http"), WithRetry(0, 0, 0),
+		WithTransport(&http.Transport{}), WithProxy(proxy.URL))
+	if _, err := c.GetAppToken(context.Background(), -1); err != nil {
+		t.Fatal(err)
+	}
+	if n := proxied.Load(); n != 1 {
+		t.Fatalf("expected request sent through proxy, got %d", n)
+	}
+}
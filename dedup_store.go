@@ -0,0 +1,140 @@
+package easemob_server_go
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DedupState 回调事件在 DedupStore 中的状态
+type DedupState int
+
+const (
+	DedupStateNew        DedupState = iota // 未处理过, 已标记为处理中
+	DedupStateProcessing                   // 正在处理, 处理完成前重试的回调不应被处理
+	DedupStateDone                         // 已处理成功
+)
+
+// DedupStore 回调事件去重存储, 环信会重试发送后回调, 同一事件可能被投递多次
+// 分布式部署时业务层可基于 Redis SET NX 等实现该接口, 处理中的标记应设置远短于已处理记录的过期时间,
+// 避免节点异常退出后事件在去重有效期内无法再被处理
+type DedupStore interface {
+	// Begin 将 key 标记为处理中, 返回标记前 key 的状态; key 已处理中或已处理时不修改记录
+	Begin(ctx context.Context, key string) (state DedupState, err error)
+	// Commit 事件处理成功后调用, 将 key 记录为已处理
+	Commit(ctx context.Context, key string) error
+	// Abort 事件处理失败时调用, 删除 key 使重试的回调可以再次被处理
+	Abort(ctx context.Context, key string) error
+}
+
+const (
+	defaultDedupCapacity      = 10000
+	defaultDedupTTL           = 10 * time.Minute
+	defaultDedupProcessingTTL = time.Minute // 处理中标记的默认有效期
+)
+
+type dedupEntry struct {
+	key      string
+	done     bool
+	expireAt time.Time
+}
+
+// MemoryDedupStore 基于内存的 DedupStore, 按 LRU 淘汰, 已处理的记录在 ttl 后失效
+// 处理中的标记在 processingTTL 后失效, 处理超时的事件可以被重试的回调再次处理
+type MemoryDedupStore struct {
+	mu            sync.Mutex
+	capacity      int
+	ttl           time.Duration
+	processingTTL time.Duration
+	entries       map[string]*list.Element
+	lru           *list.List
+}
+
+// NewMemoryDedupStore 创建基于内存的 DedupStore, capacity 为最多保留的记录数, ttl 为已处理记录的有效期
+// 处理中标记的有效期默认为1分钟且不超过 ttl, 可通过 SetProcessingTTL 修改
+func NewMemoryDedupStore(capacity int, ttl time.Duration) *MemoryDedupStore {
+	if capacity <= 0 {
+		capacity = defaultDedupCapacity
+	}
+	if ttl <= 0 {
+		ttl = defaultDedupTTL
+	}
+	return &MemoryDedupStore{capacity: capacity, ttl: ttl, processingTTL: min(defaultDedupProcessingTTL, ttl),
+		entries: make(map[string]*list.Element), lru: list.New()}
+}
+
+// SetProcessingTTL 设置处理中标记的有效期, 应大于回调处理函数的最长执行时间
+func (s *MemoryDedupStore) SetProcessingTTL(ttl time.Duration) *MemoryDedupStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ttl > 0 {
+		s.processingTTL = ttl
+	}
+	return s
+}
+
+func (s *MemoryDedupStore) Begin(_ context.Context, key string) (state DedupState, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry := s.get(key); entry != nil {
+		if entry.done {
+			return DedupStateDone, nil
+		}
+		return DedupStateProcessing, nil
+	}
+	s.put(key, false)
+	return DedupStateNew, nil
+}
+
+func (s *MemoryDedupStore) Commit(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(key, true)
+	return nil
+}
+
+func (s *MemoryDedupStore) Abort(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, exist := s.entries[key]; exist {
+		s.lru.Remove(elem)
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// get 返回未过期的记录并移至队首, 调用方需持有 mu
+func (s *MemoryDedupStore) get(key string) *dedupEntry {
+	elem, exist := s.entries[key]
+	if !exist {
+		return nil
+	}
+	if entry := elem.Value.(*dedupEntry); time.Now().Before(entry.expireAt) {
+		s.lru.MoveToFront(elem)
+		return entry
+	}
+	s.lru.Remove(elem)
+	delete(s.entries, key)
+	return nil
+}
+
+// put 写入记录并重新计算有效期, 超出容量时淘汰最久未使用的记录, 调用方需持有 mu
+func (s *MemoryDedupStore) put(key string, done bool) {
+	ttl := s.processingTTL
+	if done {
+		ttl = s.ttl
+	}
+	entry := &dedupEntry{key: key, done: done, expireAt: time.Now().Add(ttl)}
+	if elem, exist := s.entries[key]; exist {
+		elem.Value = entry
+		s.lru.MoveToFront(elem)
+		return
+	}
+	s.entries[key] = s.lru.PushFront(entry)
+	for s.lru.Len() > s.capacity {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*dedupEntry).key)
+	}
+}
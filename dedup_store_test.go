@@ -0,0 +1,92 @@
+package easemob_server_go
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func begin(t *testing.T, store DedupStore, key string) DedupState {
+	t.Helper()
+	state, err := store.Begin(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+func TestMemoryDedupStoreStates(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDedupStore(10, time.Minute)
+	if state := begin(t, store, "a"); state != DedupStateNew {
+		t.Fatalf("expected new, got %v", state)
+	}
+	if state := begin(t, store, "a"); state != DedupStateProcessing {
+		t.Fatalf("expected processing, got %v", state)
+	}
+	if err := store.Abort(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if state := begin(t, store, "a"); state != DedupStateNew {
+		t.Fatalf("expected new after abort, got %v", state)
+	}
+	if err := store.Commit(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if state := begin(t, store, "a"); state != DedupStateDone {
+		t.Fatalf("expected done after commit, got %v", state)
+	}
+}
+
+func TestMemoryDedupStoreLRUEviction(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDedupStore(2, time.Minute)
+	for _, key := range []string{"a", "b"} {
+		begin(t, store, key)
+		_ = store.Commit(ctx, key)
+	}
+	// 访问 a 后 b 成为最久未使用的记录
+	if state := begin(t, store, "a"); state != DedupStateDone {
+		t.Fatalf("expected a done, got %v", state)
+	}
+	begin(t, store, "c")
+	if state := begin(t, store, "b"); state != DedupStateNew {
+		t.Fatalf("expected b evicted, got %v", state)
+	}
+	if state := begin(t, store, "c"); state != DedupStateProcessing {
+		t.Fatalf("expected c kept, got %v", state)
+	}
+}
+
+func TestMemoryDedupStoreTTL(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDedupStore(10, 50*time.Millisecond)
+	begin(t, store, "a")
+	_ = store.Commit(ctx, "a")
+	if state := begin(t, store, "a"); state != DedupStateDone {
+		t.Fatalf("expected done within ttl, got %v", state)
+	}
+	time.Sleep(80 * time.Millisecond)
+	if state := begin(t, store, "a"); state != DedupStateNew {
+		t.Fatalf("expected record expired after ttl, got %v", state)
+	}
+}
+
+func TestMemoryDedupStoreProcessingTTL(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDedupStore(10, time.Minute).SetProcessingTTL(50 * time.Millisecond)
+	begin(t, store, "a")
+	if state := begin(t, store, "a"); state != DedupStateProcessing {
+		t.Fatalf("expected processing, got %v", state)
+	}
+	time.Sleep(80 * time.Millisecond)
+	if state := begin(t, store, "a"); state != DedupStateNew {
+		t.Fatalf("expected processing mark expired, got %v", state)
+	}
+	// 处理成功后使用已处理记录的有效期
+	_ = store.Commit(ctx, "a")
+	time.Sleep(80 * time.Millisecond)
+	if state := begin(t, store, "a"); state != DedupStateDone {
+		t.Fatalf("expected done record kept for ttl, got %v", state)
+	}
+}
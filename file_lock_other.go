@@ -0,0 +1,16 @@
+//go:build !(unix && !aix) && !windows
+
+package easemob_server_go
+
+import (
+	"errors"
+	"os"
+)
+
+func lockFile(*os.File) error {
+	return errors.New("file lock is not supported on this platform")
+}
+
+func unlockFile(*os.File) error {
+	return nil
+}
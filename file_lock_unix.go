@@ -0,0 +1,18 @@
+//go:build unix && !aix
+
+package easemob_server_go
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// lockFile 对 file 加排他锁, 阻塞直到加锁成功
+func lockFile(file *os.File) error {
+	return unix.Flock(int(file.Fd()), unix.LOCK_EX)
+}
+
+func unlockFile(file *os.File) error {
+	return unix.Flock(int(file.Fd()), unix.LOCK_UN)
+}
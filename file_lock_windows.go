@@ -0,0 +1,18 @@
+//go:build windows
+
+package easemob_server_go
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile 对 file 加排他锁, 阻塞直到加锁成功
+func lockFile(file *os.File) error {
+	return windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, new(windows.Overlapped))
+}
+
+func unlockFile(file *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, new(windows.Overlapped))
+}
@@ -4,7 +4,10 @@ go 1.23.0
 
 toolchain go1.23.9
 
-require github.com/imroc/req/v3 v3.52.2
+require (
+	github.com/imroc/req/v3 v3.52.2
+	golang.org/x/sys v0.32.0
+)
 
 require (
 	github.com/andybalholm/brotli v1.1.1 // indirect
@@ -25,7 +28,6 @@ require (
 	golang.org/x/mod v0.24.0 // indirect
 	golang.org/x/net v0.39.0 // indirect
 	golang.org/x/sync v0.13.0 // indirect
-	golang.org/x/sys v0.32.0 // indirect
 	golang.org/x/text v0.24.0 // indirect
 	golang.org/x/tools v0.32.0 // indirect
 )
@@ -0,0 +1,193 @@
+package easemob_server_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenStore AppToken 共享存储, 用于分布式部署时多个节点共用同一个 AppToken
+// 节点在访问 token 接口前先从 TokenStore 读取, 并通过租约保证同一时间只有一个节点刷新 AppToken
+// 业务层可基于 Redis 等实现该接口
+type TokenStore interface {
+	// Get 获取 token, 不存在时返回空字符串; expireAt 为零值表示永不过期
+	Get(ctx context.Context, key string) (token string, expireAt time.Time, err error)
+	// Set 保存 token, expireAt 为零值表示永不过期
+	Set(ctx context.Context, key, token string, expireAt time.Time) error
+	// Lock 尝试获取刷新 token 的租约, 租约在 ttl 后自动失效; 已被其他 owner 持有时返回 false
+	Lock(ctx context.Context, key, owner string, ttl time.Duration) (ok bool, err error)
+	// Unlock 释放租约, 仅当租约由 owner 持有时生效
+	Unlock(ctx context.Context, key, owner string) error
+}
+
+type tokenRecord struct {
+	Token    string    `json:"token"`
+	ExpireAt time.Time `json:"expireAt"`
+}
+
+type tokenLease struct {
+	Owner    string    `json:"owner"`
+	ExpireAt time.Time `json:"expireAt"`
+}
+
+// MemoryTokenStore 基于内存的 TokenStore, 适用于同一进程内的多个 Client 共享 AppToken
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]tokenRecord
+	leases map[string]tokenLease
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]tokenRecord), leases: make(map[string]tokenLease)}
+}
+
+func (s *MemoryTokenStore) Get(_ context.Context, key string) (token string, expireAt time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record := s.tokens[key]
+	return record.Token, record.ExpireAt, nil
+}
+
+func (s *MemoryTokenStore) Set(_ context.Context, key, token string, expireAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[key] = tokenRecord{Token: token, ExpireAt: expireAt}
+	return nil
+}
+
+func (s *MemoryTokenStore) Lock(_ context.Context, key, owner string, ttl time.Duration) (ok bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lease, exist := s.leases[key]; exist && lease.Owner != owner && time.Now().Before(lease.ExpireAt) {
+		return false, nil
+	}
+	s.leases[key] = tokenLease{Owner: owner, ExpireAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (s *MemoryTokenStore) Unlock(_ context.Context, key, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lease, exist := s.leases[key]; exist && lease.Owner == owner {
+		delete(s.leases, key)
+	}
+	return nil
+}
+
+// FileTokenStore 基于文件的 TokenStore, 适用于同一台机器上的多个进程共享 AppToken
+// token 保存在 <dir>/<key>.token, 租约保存在 <dir>/<key>.lock
+// 租约的读取及写入在 <dir>/<key>.guard 的文件锁(flock/LockFileEx)内进行, 网络文件系统上文件锁可能不可靠
+type FileTokenStore struct {
+	dir string
+}
+
+func NewFileTokenStore(dir string) (store *FileTokenStore, err error) {
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileTokenStore{dir: dir}, nil
+}
+
+func (s *FileTokenStore) path(key, ext string) string {
+	return filepath.Join(s.dir, strings.NewReplacer("#", "_", "/", "_", "\\", "_").Replace(key)+ext)
+}
+
+func (s *FileTokenStore) Get(_ context.Context, key string) (token string, expireAt time.Time, err error) {
+	var record tokenRecord
+	if err = readJsonFile(s.path(key, ".token"), &record); errors.Is(err, os.ErrNotExist) {
+		return "", time.Time{}, nil
+	} else if err != nil {
+		return "", time.Time{}, err
+	}
+	return record.Token, record.ExpireAt, nil
+}
+
+func (s *FileTokenStore) Set(_ context.Context, key, token string, expireAt time.Time) error {
+	return s.writeJsonFile(s.path(key, ".token"), tokenRecord{Token: token, ExpireAt: expireAt})
+}
+
+func (s *FileTokenStore) Lock(_ context.Context, key, owner string, ttl time.Duration) (ok bool, err error) {
+	err = s.withGuard(key, func(lockPath string) error {
+		var lease tokenLease
+		if err := readJsonFile(lockPath, &lease); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		} else if err == nil && lease.Owner != owner && time.Now().Before(lease.ExpireAt) {
+			return nil
+		}
+		if err := s.writeJsonFile(lockPath, tokenLease{Owner: owner, ExpireAt: time.Now().Add(ttl)}); err != nil {
+			return err
+		}
+		ok = true
+		return nil
+	})
+	return
+}
+
+func (s *FileTokenStore) Unlock(_ context.Context, key, owner string) error {
+	return s.withGuard(key, func(lockPath string) error {
+		var lease tokenLease
+		if err := readJsonFile(lockPath, &lease); errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		} else if lease.Owner != owner {
+			return nil
+		}
+		if err := os.Remove(lockPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	})
+}
+
+// withGuard 持有 key 对应的文件锁执行 fn, 保证租约的检查与写入不会与其他进程交错
+func (s *FileTokenStore) withGuard(key string, fn func(lockPath string) error) (err error) {
+	guard, err := os.OpenFile(s.path(key, ".guard"), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	defer guard.Close()
+	if err = lockFile(guard); err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := unlockFile(guard); err == nil {
+			err = unlockErr
+		}
+	}()
+	return fn(s.path(key, ".lock"))
+}
+
+// writeJsonFile 先写临时文件再重命名, 避免其他进程读到写了一半的内容
+func (s *FileTokenStore) writeJsonFile(name string, v any) error {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err = tmpFile.Write(jsonBytes); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), name)
+}
+
+func readJsonFile(name string, v any) error {
+	jsonBytes, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonBytes, v)
+}
@@ -0,0 +1,123 @@
+package easemob_server_go
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// contendLease 让多个 owner 并发获取同一个租约, 返回获取成功的数量
+func contendLease(t *testing.T, stores []TokenStore, ttl time.Duration) int32 {
+	t.Helper()
+	var wg sync.WaitGroup
+	var acquired atomic.Int32
+	for i, store := range stores {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := store.Lock(context.Background(), "org#app", fmt.Sprintf("owner-%d", i), ttl)
+			if err != nil {
+				t.Error(err)
+			} else if ok {
+				acquired.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	return acquired.Load()
+}
+
+func testTokenStoreLease(t *testing.T, newStore func() TokenStore) {
+	ctx := context.Background()
+	stores := make([]TokenStore, 20)
+	for i := range stores {
+		stores[i] = newStore()
+	}
+	if n := contendLease(t, stores, time.Minute); n != 1 {
+		t.Fatalf("expected exactly 1 owner to acquire the lease, got %d", n)
+	}
+	// 其他 owner 无法释放不属于自己的租约
+	for i := range stores {
+		if err := stores[i].Unlock(ctx, "org#app", "intruder"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ok, err := stores[0].Lock(ctx, "org#app", "late", time.Minute); err != nil || ok {
+		t.Fatalf("lease must still be held after foreign unlock, ok=%v err=%v", ok, err)
+	}
+	// 过期租约同一时间只能被一个 owner 接管
+	for i := range stores {
+		for j := range stores {
+			_ = stores[i].Unlock(ctx, "org#app", fmt.Sprintf("owner-%d", j))
+		}
+	}
+	if n := contendLease(t, stores, 50*time.Millisecond); n != 1 {
+		t.Fatalf("expected exactly 1 owner to acquire the lease, got %d", n)
+	}
+	time.Sleep(80 * time.Millisecond)
+	if n := contendLease(t, stores, time.Minute); n != 1 {
+		t.Fatalf("expected exactly 1 owner to take over the expired lease, got %d", n)
+	}
+}
+
+func TestMemoryTokenStoreLease(t *testing.T) {
+	store := NewMemoryTokenStore()
+	testTokenStoreLease(t, func() TokenStore { return store })
+}
+
+func TestFileTokenStoreLease(t *testing.T) {
+	dir := t.TempDir()
+	// 每个 owner 使用独立的 FileTokenStore, 与多进程各自打开文件的情况一致
+	testTokenStoreLease(t, func() TokenStore {
+		store, err := NewFileTokenStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return store
+	})
+}
+
+func TestFileTokenStoreGetSet(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileTokenStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token, _, err := store.Get(ctx, "org#app"); err != nil || token != "" {
+		t.Fatalf("expected empty token, got %q err=%v", token, err)
+	}
+	expireAt := time.Now().Add(time.Hour).Round(0)
+	if err = store.Set(ctx, "org#app", "token-1", expireAt); err != nil {
+		t.Fatal(err)
+	}
+	token, gotExpireAt, err := store.Get(ctx, "org#app")
+	if err != nil || token != "token-1" || !gotExpireAt.Equal(expireAt) {
+		t.Fatalf("got %q %v err=%v", token, gotExpireAt, err)
+	}
+}
+
+func TestTokenStoreSharedRefresh(t *testing.T) {
+	s := &tokenServer{expiresIn: 3600 * 1000, tokenDelay: 50 * time.Millisecond}
+	store := NewMemoryTokenStore()
+	clients := make([]*Client, 5)
+	for i := range clients {
+		clients[i] = newTokenTestClient(t, s, WithTokenStore(store))
+	}
+	var wg sync.WaitGroup
+	for _, c := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := ping(context.Background(), c); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := s.tokenCalls.Load(); n != 1 {
+		t.Fatalf("expected nodes sharing a TokenStore to fetch the token once, got %d", n)
+	}
+}